@@ -1,33 +1,147 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/breaker"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
 	"github.com/kozennoki/nerine/internal/infrastructure/config"
+	"github.com/kozennoki/nerine/internal/infrastructure/metrics"
 	"github.com/kozennoki/nerine/internal/infrastructure/microcms"
+	"github.com/kozennoki/nerine/internal/infrastructure/popularity"
+	"github.com/kozennoki/nerine/internal/infrastructure/preview"
+	"github.com/kozennoki/nerine/internal/infrastructure/ratelimit"
+	"github.com/kozennoki/nerine/internal/infrastructure/retry"
+	"github.com/kozennoki/nerine/internal/infrastructure/tracing"
 	"github.com/kozennoki/nerine/internal/infrastructure/zenn"
 	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
 	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 type DIContainer struct {
-	APIHandler *handlers.APIHandler
+	APIHandler       *handlers.APIHandler
+	WebhookHandler   *handlers.WebhookHandler
+	ReadinessHandler *handlers.ReadinessHandler
+	LogLevelHandler  *handlers.LogLevelHandler
+	ViewHandler      *handlers.ArticleViewHandler
+	PreviewHandler   *handlers.PreviewHandler
+	PreviewSealer    *preview.Sealer
+	ErrorRenderer    *handlers.ErrorRenderer
+	Metrics          *metrics.Metrics
+	KeyRing          *middleware.KeyRing
+	RateLimitStore   ratelimit.Store
+
+	// closers stop background work, in the order they were added.
+	closers []func()
 }
 
-func NewDIContainer(cfg *config.Config) *DIContainer {
+func NewDIContainer(cfg *config.Config, logger *zap.Logger, logLevel zap.AtomicLevel, tracer trace.Tracer) (*DIContainer, error) {
+	// Stores that connect to Redis are created first, so that nothing else
+	// has to be released when their configuration is rejected.
+	rateLimitStore, closeRateLimitStore, err := newRateLimitStore(cfg.RateLimit, logger)
+	if err != nil {
+		return nil, err
+	}
+	popularityStore, closePopularityStore, err := newPopularityStore(cfg.Popularity, logger)
+	if err != nil {
+		closeRateLimitStore()
+		return nil, err
+	}
+
 	// Repository
-	articleRepo := microcms.NewArticleRepository(cfg.MicroCMSAPIKey, cfg.MicroCMSServiceID)
-	categoryRepo := microcms.NewCategoryRepository(cfg.MicroCMSAPIKey, cfg.MicroCMSServiceID)
-	zennRepo := zenn.NewZennRepository()
-
-	// UseCase
-	getArticlesUsecase := usecase.NewGetArticles(articleRepo)
-	getArticleByIDUsecase := usecase.NewGetArticleByID(articleRepo)
-	getPopularArticlesUsecase := usecase.NewGetPopularArticles(articleRepo)
-	getLatestArticlesUsecase := usecase.NewGetLatestArticles(articleRepo)
-	getArticlesByCategoryUsecase := usecase.NewGetArticlesByCategory(articleRepo)
-	getCategoriesUsecase := usecase.NewGetCategories(categoryRepo)
-	getZennArticlesUsecase := usecase.NewGetZennArticles(zennRepo)
+	microCMSClient := microcms.NewClient(cfg.MicroCMSAPIKey, cfg.MicroCMSServiceID,
+		microcms.WithTimeout(cfg.MicroCMSTimeout),
+		microcms.WithTracing(),
+		microcms.WithRetry(newRetryPolicy(cfg.MicroCMSRetry)),
+	)
+	var articleRepo repository.ArticleRepository = microcms.NewArticleRepository(microCMSClient)
+	var categoryRepo repository.CategoryRepository = microcms.NewCategoryRepository(microCMSClient)
+	zennRepo := zenn.NewZennRepository(
+		zenn.WithTracing(),
+		zenn.WithRetry(newRetryPolicy(cfg.ZennRetry)),
+	)
+
+	// Metrics of the calls that reach the upstreams
+	appMetrics := metrics.New()
+	articleRepo = metrics.NewArticleRepository(articleRepo, appMetrics, "microcms")
+	categoryRepo = metrics.NewCategoryRepository(categoryRepo, appMetrics, "microcms")
+	zennRepo = metrics.NewZennRepository(zennRepo, appMetrics, "zenn")
+
+	// Circuit breakers, one per upstream. They sit below the cache so that
+	// stale entries can still be served while a circuit is open.
+	breakerSettings := newBreakerSettings(cfg.CircuitBreaker)
+	microCMSBreaker := breaker.New("microcms", breakerSettings, logger)
+	zennBreaker := breaker.New("zenn", breakerSettings, logger)
+	articleRepo = breaker.NewArticleRepository(articleRepo, microCMSBreaker)
+	categoryRepo = breaker.NewCategoryRepository(categoryRepo, microCMSBreaker)
+	zennRepo = breaker.NewZennRepository(zennRepo, zennBreaker)
+
+	// Readiness probes bypass the cache so that they reach the upstreams.
+	readinessDependencies := []usecase.ReadinessDependency{
+		{Name: "microcms", Required: true, Reader: articleRepo},
+		{Name: "zenn", Required: cfg.Readiness.ZennRequired, Reader: zennRepo},
+	}
+
+	// Popular articles ranked by page views. It sits below the cache, so the
+	// ranked list is cached like the other lists.
+	popularityTracker := popularity.NewTracker(popularityStore, popularity.Options{
+		Window:   cfg.Popularity.Window,
+		HalfLife: cfg.Popularity.HalfLife,
+		Dedup:    cfg.Popularity.DedupWindow,
+	})
+	articleRepo = popularity.NewArticleRepository(articleRepo, popularityTracker)
+
+	// Cache and request coalescing. With the cache disabled every TTL is zero,
+	// so identical concurrent calls are still merged but nothing is stored.
+	store := cache.NewStore(cfg.Cache.MaxEntries)
+	appMetrics.RegisterCache(store)
+	opts := newCacheOptions(cfg.Cache)
+	articleRepo = cache.NewArticleRepository(articleRepo, store, opts)
+	categoryRepo = cache.NewCategoryRepository(categoryRepo, store, opts)
+	zennRepo = cache.NewZennRepository(zennRepo, store, opts)
+
+	// Spans for repository calls, including those answered by the cache
+	articleRepo = tracing.NewArticleRepository(articleRepo, tracer, "microcms")
+	categoryRepo = tracing.NewCategoryRepository(categoryRepo, tracer, "microcms")
+	zennRepo = tracing.NewZennRepository(zennRepo, tracer, "zenn")
+
+	// UseCase, each Exec in its own span
+	getArticlesUsecase := tracing.NewUsecase[usecase.GetArticlesUsecaseInput, usecase.GetArticlesUsecaseOutput](
+		usecase.NewGetArticles(articleRepo), tracer, "GetArticles")
+	getArticleByIDUsecase := tracing.NewUsecase[usecase.GetArticleByIDUsecaseInput, usecase.GetArticleByIDUsecaseOutput](
+		usecase.NewGetArticleByID(articleRepo), tracer, "GetArticleByID")
+	getPopularArticlesUsecase := tracing.NewUsecase[usecase.GetPopularArticlesUsecaseInput, usecase.GetPopularArticlesUsecaseOutput](
+		usecase.NewGetPopularArticles(articleRepo), tracer, "GetPopularArticles")
+	getLatestArticlesUsecase := tracing.NewUsecase[usecase.GetLatestArticlesUsecaseInput, usecase.GetLatestArticlesUsecaseOutput](
+		usecase.NewGetLatestArticles(articleRepo), tracer, "GetLatestArticles")
+	getArticlesByCategoryUsecase := tracing.NewUsecase[usecase.GetArticlesByCategoryUsecaseInput, usecase.GetArticlesByCategoryUsecaseOutput](
+		usecase.NewGetArticlesByCategory(articleRepo), tracer, "GetArticlesByCategory")
+	getCategoriesUsecase := tracing.NewUsecase[usecase.GetCategoriesUsecaseInput, usecase.GetCategoriesUsecaseOutput](
+		usecase.NewGetCategories(categoryRepo), tracer, "GetCategories")
+	getZennArticlesUsecase := tracing.NewUsecase[usecase.GetZennArticlesUsecaseInput, usecase.GetZennArticlesUsecaseOutput](
+		usecase.NewGetZennArticles(zennRepo), tracer, "GetZennArticles")
+	recordArticleViewUsecase := tracing.NewUsecase[usecase.RecordArticleViewUsecaseInput, usecase.RecordArticleViewUsecaseOutput](
+		usecase.NewRecordArticleView(articleRepo, popularityTracker), tracer, "RecordArticleView")
+	purgeContentUsecase := tracing.NewUsecase[usecase.PurgeContentUsecaseInput, usecase.PurgeContentUsecaseOutput](
+		usecase.NewPurgeContent(cache.NewInvalidator(store)), tracer, "PurgeContent")
+	upstreamMonitor := breaker.NewMonitor(microCMSBreaker, zennBreaker)
+	appMetrics.RegisterUpstreamMonitor(upstreamMonitor)
+	getHealthUsecase := tracing.NewUsecase[usecase.GetHealthUsecaseInput, usecase.GetHealthUsecaseOutput](
+		usecase.NewGetHealth(upstreamMonitor), tracer, "GetHealth")
+	getReadinessUsecase := tracing.NewUsecase[usecase.GetReadinessUsecaseInput, usecase.GetReadinessUsecaseOutput](
+		usecase.NewGetReadiness(readinessDependencies, usecase.ReadinessOptions{
+			CacheTTL:     cfg.Readiness.CacheTTL,
+			ProbeTimeout: cfg.Readiness.ProbeTimeout,
+		}), tracer, "GetReadiness")
 
 	// Handler
+	errorRenderer := handlers.NewErrorRenderer(cfg.Debug)
 	apiHandler := handlers.NewAPIHandler(
 		getArticlesUsecase,
 		getArticleByIDUsecase,
@@ -36,9 +150,161 @@ func NewDIContainer(cfg *config.Config) *DIContainer {
 		getArticlesByCategoryUsecase,
 		getCategoriesUsecase,
 		getZennArticlesUsecase,
+		getHealthUsecase,
+		errorRenderer,
 	)
 
+	webhookHandler := handlers.NewWebhookHandler(purgeContentUsecase, errorRenderer)
+	readinessHandler := handlers.NewReadinessHandler(getReadinessUsecase, errorRenderer, cfg.Debug)
+	logLevelHandler := handlers.NewLogLevelHandler(logLevel, errorRenderer)
+	viewHandler := handlers.NewArticleViewHandler(recordArticleViewUsecase, errorRenderer)
+	previewSealer := preview.NewSealer(cfg.Preview.TokenSecret)
+	previewHandler := handlers.NewPreviewHandler(previewSealer, getArticleByIDUsecase, errorRenderer,
+		cfg.Preview.TokenTTL, cfg.Preview.MaxTokenTTL)
+
 	return &DIContainer{
-		APIHandler: apiHandler,
+		APIHandler:       apiHandler,
+		WebhookHandler:   webhookHandler,
+		ReadinessHandler: readinessHandler,
+		LogLevelHandler:  logLevelHandler,
+		ViewHandler:      viewHandler,
+		PreviewHandler:   previewHandler,
+		PreviewSealer:    previewSealer,
+		ErrorRenderer:    errorRenderer,
+		Metrics:          appMetrics,
+		KeyRing:          newKeyRing(cfg, logger),
+		RateLimitStore:   rateLimitStore,
+		closers:          []func(){store.Close, closeRateLimitStore, closePopularityStore},
+	}, nil
+}
+
+// Close stops the background workers, such as cache revalidations, and waits
+// for them to return. It must be called after the server has stopped serving.
+func (di *DIContainer) Close() {
+	for _, closer := range di.closers {
+		closer()
+	}
+}
+
+func newCacheOptions(cfg config.CacheConfig) cache.Options {
+	if !cfg.Enabled {
+		return cache.Options{}
+	}
+
+	return cache.Options{
+		NegativeTTL:          cfg.NegativeTTL,
+		StaleWhileRevalidate: cfg.StaleWhileRevalidate,
+		StaleIfError:         cfg.StaleIfError,
+		RevalidateTimeout:    cfg.RevalidateTimeout,
+		TTL: cache.TTL{
+			Articles:           cfg.TTL.Articles,
+			Article:            cfg.TTL.Article,
+			ArticlesByCategory: cfg.TTL.ArticlesByCategory,
+			PopularArticles:    cfg.TTL.PopularArticles,
+			LatestArticles:     cfg.TTL.LatestArticles,
+			Categories:         cfg.TTL.Categories,
+			Category:           cfg.TTL.Category,
+			ZennArticles:       cfg.TTL.ZennArticles,
+		},
+	}
+}
+
+func newRetryPolicy(cfg config.RetryConfig) retry.Policy {
+	return retry.Policy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseDelay:   cfg.BaseDelay,
+		MaxDelay:    cfg.MaxDelay,
+	}
+}
+
+func newBreakerSettings(cfg config.CircuitBreakerConfig) breaker.Settings {
+	return breaker.Settings{
+		FailureRatio:     cfg.FailureRatio,
+		MinRequests:      uint32(cfg.MinRequests),
+		Interval:         cfg.Interval,
+		Cooldown:         cfg.Cooldown,
+		HalfOpenRequests: uint32(cfg.HalfOpenRequests),
+	}
+}
+
+// newKeyRing builds the API key ring from NERINE_API_KEYS and the legacy
+// NERINE_API_KEY. The legacy key is shipped with the browser frontend, so it
+// only gets the read scope; preview and admin need a named key. Keys that have
+// already expired are kept, so that their use is reported as expired rather
+// than invalid, but logged at startup.
+func newKeyRing(cfg *config.Config, logger *zap.Logger) *middleware.KeyRing {
+	keys := make([]middleware.APIKey, 0, len(cfg.APIKeys)+1)
+	if cfg.NerineAPIKey != "" {
+		keys = append(keys, middleware.APIKey{
+			Name:   config.DefaultAPIKeyName,
+			SHA256: middleware.HashAPIKey(cfg.NerineAPIKey),
+			Scopes: []middleware.Scope{middleware.ScopeRead},
+		})
+	}
+
+	now := time.Now()
+	for _, key := range cfg.APIKeys {
+		scopes := make([]middleware.Scope, len(key.Scopes))
+		for i, scope := range key.Scopes {
+			scopes[i] = middleware.Scope(scope)
+		}
+		keys = append(keys, middleware.APIKey{
+			Name:      key.Name,
+			SHA256:    key.SHA256,
+			Scopes:    scopes,
+			ExpiresAt: key.ExpiresAt,
+		})
+		if !key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt) {
+			logger.Warn("API key has expired", zap.String("api_key", key.Name), zap.Time("expires_at", key.ExpiresAt))
+		}
+	}
+	return middleware.NewKeyRing(keys)
+}
+
+// newRateLimitStore returns the store of the rate limit buckets and a function
+// releasing it.
+func newRateLimitStore(cfg config.RateLimitConfig, logger *zap.Logger) (ratelimit.Store, func(), error) {
+	if cfg.Store != "redis" {
+		return ratelimit.NewMemoryStore(), func() {}, nil
+	}
+
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse RATE_LIMIT_REDIS_URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+	return ratelimit.NewRedisStore(client), func() {
+		if err := client.Close(); err != nil {
+			logger.Error("Failed to close the rate limit store", zap.Error(err))
+		}
+	}, nil
+}
+
+// newPopularityStore returns the store of the page views and a function that
+// releases it. The memory store is restored from and saved to its snapshot.
+func newPopularityStore(cfg config.PopularityConfig, logger *zap.Logger) (popularity.Store, func(), error) {
+	if cfg.Store == "redis" {
+		opts, err := redis.ParseURL(cfg.RedisURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse POPULARITY_REDIS_URL: %w", err)
+		}
+		client := redis.NewClient(opts)
+		return popularity.NewRedisStore(client, cfg.Window), func() {
+			if err := client.Close(); err != nil {
+				logger.Error("Failed to close the popularity store", zap.Error(err))
+			}
+		}, nil
+	}
+
+	store := popularity.NewMemoryStore(cfg.Window)
+	if cfg.SnapshotPath == "" {
+		return store, func() {}, nil
+	}
+	if err := store.Load(cfg.SnapshotPath); err != nil {
+		logger.Error("Failed to restore the popularity snapshot", zap.Error(err))
 	}
+	stop := store.SaveEvery(cfg.SnapshotPath, cfg.SnapshotInterval, func(err error) {
+		logger.Error("Failed to save the popularity snapshot", zap.Error(err))
+	})
+	return store, stop, nil
 }
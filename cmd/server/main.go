@@ -1,33 +1,122 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kozennoki/nerine/internal/infrastructure/config"
 	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/infrastructure/tracing"
 	"go.uber.org/zap"
 )
 
+// Exit codes of the server process.
+const (
+	exitOK = 0
+	// exitError means the server failed while starting, serving or draining
+	// requests.
+	exitError = 1
+	// exitConfig means the configuration is invalid (EX_CONFIG in sysexits.h),
+	// so restarting without changing it will not help.
+	exitConfig = 78
+)
+
 func main() {
+	os.Exit(run())
+}
+
+func run() int {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found")
 	}
 
-	zapLogger, err := logger.New()
+	// The logger is configured from the environment, so configuration errors
+	// are reported with the standard logger.
+	cfg, err := config.Load()
+	if err != nil {
+		log.Println("Failed to load config:", err)
+		return exitConfig
+	}
+
+	zapLogger, logLevel, err := logger.New(newLoggerConfig(cfg.Log))
 	if err != nil {
-		log.Fatal("Failed to initialize logger:", err)
+		log.Println("Failed to initialize logger:", err)
+		return exitConfig
 	}
 	defer zapLogger.Sync()
+	zap.ReplaceGlobals(zapLogger)
 
-	cfg, err := config.Load()
+	// The first SIGINT or SIGTERM starts a graceful shutdown. Once ctx is done
+	// the default handling is restored, so a second signal stops at once.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	tracingProvider, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    tracing.Exporter(cfg.Tracing.Exporter),
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
 	if err != nil {
-		zapLogger.Fatal("Failed to load config", zap.Error(err))
+		zapLogger.Error("Failed to set up tracing", zap.Error(err))
+		return exitError
 	}
 
-	e := setupServer(cfg, zapLogger)
+	// Setting up fails only on configuration that config.Load cannot check,
+	// such as a Redis URL rejected by the client.
+	e, di, err := setupServer(cfg, zapLogger, logLevel, tracingProvider.Tracer())
+	if err != nil {
+		zapLogger.Error("Failed to set up the server", zap.Error(err))
+		if err := shutdownTracing(tracingProvider, cfg.ShutdownTimeout); err != nil {
+			zapLogger.Error("Failed to flush spans", zap.Error(err))
+		}
+		return exitConfig
+	}
+
+	code := exitOK
+	if err := runServer(ctx, e, cfg, zapLogger); err != nil {
+		zapLogger.Error("Server stopped with an error", zap.Error(err))
+		code = exitError
+	}
+
+	di.Close()
+	if err := shutdownTracing(tracingProvider, cfg.ShutdownTimeout); err != nil {
+		zapLogger.Error("Failed to flush spans", zap.Error(err))
+	}
+	zapLogger.Info("Server stopped", zap.Int("exit_code", code))
+	return code
+}
+
+// shutdownTracing exports the remaining spans. It runs after the server has
+// stopped, so it gets a fresh timeout rather than the signal context.
+func shutdownTracing(provider *tracing.Provider, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return provider.Shutdown(ctx)
+}
 
-	if err := startServer(e, cfg, zapLogger); err != nil {
-		zapLogger.Fatal("Failed to start server", zap.Error(err))
+func newLoggerConfig(cfg config.LogConfig) logger.Config {
+	return logger.Config{
+		Level:  cfg.Level,
+		Format: logger.Format(cfg.Format),
+		Sampling: logger.SamplingConfig{
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+		},
+		File: logger.FileConfig{
+			Path:       cfg.File.Path,
+			MaxSizeMB:  cfg.File.MaxSizeMB,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAgeDays: cfg.File.MaxAgeDays,
+			Compress:   cfg.File.Compress,
+		},
 	}
 }
@@ -2,27 +2,211 @@ package main
 
 import (
 	"github.com/kozennoki/nerine/internal/infrastructure/config"
+	"github.com/kozennoki/nerine/internal/infrastructure/ratelimit"
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
 	"github.com/kozennoki/nerine/internal/interfaces/middleware"
 	"github.com/kozennoki/nerine/internal/openapi"
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.uber.org/zap"
 )
 
-func setupRoutes(e *echo.Echo, di *DIContainer, cfg *config.Config) {
-	// CORS middleware
-	e.Use(echomiddleware.CORS())
+const (
+	microCMSWebhookPath = "/webhooks/microcms"
+	readinessPath       = "/ready"
+	metricsPath         = "/metrics"
+	logLevelPath        = "/admin/log-level"
+	articlePath         = "/api/v1/articles/:id"
+	articleViewsPath    = "/api/v1/articles/:id/views"
+	previewTokensPath   = "/preview/tokens"
+	previewArticlePath  = "/preview/articles/:id"
+)
+
+// operationalPaths are served without an API key and without conditional
+// responses.
+var operationalPaths = map[string]bool{
+	"/health":     true,
+	readinessPath: true,
+	metricsPath:   true,
+}
+
+// adminPaths need an API key with the admin scope and are served without
+// conditional responses. Every other authenticated route needs read, except
+// previews (see isPreview).
+var adminPaths = map[string]bool{
+	logLevelPath: true,
+}
+
+// previewPaths serve article drafts and are never served from a cache.
+// Issuing a preview link needs the preview scope; the links themselves are
+// authenticated by their token instead of an API key.
+var previewPaths = map[string]bool{
+	previewTokensPath:  true,
+	previewArticlePath: true,
+}
+
+// isPreview reports whether the request reads an article draft. Previews need
+// an API key with the preview scope and are never served from a cache.
+func isPreview(c echo.Context) bool {
+	return c.Path() == articlePath && c.QueryParam(handlers.DraftKeyParam) != ""
+}
+
+func setupRoutes(e *echo.Echo, di *DIContainer, cfg *config.Config, logger *zap.Logger) {
+	// Server span per request, continuing the trace of an incoming traceparent
+	e.Use(otelecho.Middleware(cfg.Tracing.ServiceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		return c.Path() == metricsPath
+	})))
+
+	// Request ID, echoed in X-Request-ID and in error responses
+	e.Use(echomiddleware.RequestID())
+
+	// Request count and latency per route and status
+	e.Use(middleware.Metrics(di.Metrics))
+
+	// One access log line per request, and a request-scoped logger in the
+	// request context
+	e.Use(middleware.AccessLog(logger))
+
+	// Turn panics into errors for the error handler
+	e.Use(echomiddleware.Recover())
+
+	// CORS policy for browser clients; preflights are answered here, before
+	// authentication
+	if len(cfg.CORS.AllowOrigins) == 0 {
+		logger.Warn("No CORS origins are allowed; browser clients will be blocked")
+	}
+	e.Use(middleware.CORS(middleware.CORSConfig{
+		AllowOrigins: cfg.CORS.AllowOrigins,
+		AllowHeaders: cfg.CORS.AllowHeaders,
+		ExposeHeaders: []string{
+			echo.HeaderXRequestID,
+			middleware.HeaderCacheStatus,
+			middleware.HeaderETag,
+			middleware.HeaderRateLimitLimit,
+			middleware.HeaderRateLimitRemaining,
+			middleware.HeaderRateLimitReset,
+			echo.HeaderRetryAfter,
+		},
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}))
 
-	// API key authentication middleware for generated routes
-	apiKeyMiddleware := middleware.APIKeyAuth(cfg.NerineAPIKey)
+	// Report cache hits and stale responses
+	e.Use(middleware.CacheStatus())
+
+	// API key authentication with the scope each route needs.
+	// Webhooks and preview links authenticate with their signature or token instead.
+	rateLimitCfg := newRateLimitConfig(cfg, di.RateLimitStore)
+	apiKeyAuth := func(scope middleware.Scope) echo.MiddlewareFunc {
+		auth := middleware.APIKeyAuth(di.KeyRing, scope)
+		if cfg.RateLimit.Enabled {
+			// Requests without a known key are limited per client IP before
+			// the key is checked, so that key guesses count against a limit.
+			auth = middleware.LimitAuthFailures(rateLimitCfg, di.KeyRing, auth)
+		}
+		return middleware.CountAuthFailures(di.Metrics, "api_key", auth)
+	}
+	readAuth := apiKeyAuth(middleware.ScopeRead)
+	previewAuth := apiKeyAuth(middleware.ScopePreview)
+	adminAuth := apiKeyAuth(middleware.ScopeAdmin)
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if c.Path() == "/health" {
+			switch {
+			case operationalPaths[c.Path()] || c.Path() == microCMSWebhookPath || c.Path() == previewArticlePath:
 				return next(c)
+			case adminPaths[c.Path()]:
+				return adminAuth(next)(c)
+			case isPreview(c) || c.Path() == previewTokensPath:
+				return previewAuth(next)(c)
+			default:
+				return readAuth(next)(c)
 			}
-			return apiKeyMiddleware(next)(c)
 		}
 	})
 
+	// Rate limits per API key, or per client IP on routes without one
+	if cfg.RateLimit.Enabled {
+		e.Use(middleware.RateLimit(rateLimitCfg))
+	}
+
+	// ETag / Last-Modified conditional responses for read endpoints
+	e.Use(middleware.Conditional(middleware.ConditionalConfig{
+		Skipper: func(c echo.Context) bool {
+			return operationalPaths[c.Path()] || adminPaths[c.Path()] || previewPaths[c.Path()] || isPreview(c)
+		},
+		CacheControl: map[string]string{
+			"/api/v1/articles":                  cfg.HTTPCache.Articles,
+			articlePath:                         cfg.HTTPCache.Article,
+			"/api/v1/articles/latest":           cfg.HTTPCache.LatestArticles,
+			"/api/v1/articles/popular":          cfg.HTTPCache.PopularArticles,
+			"/api/v1/categories":                cfg.HTTPCache.Categories,
+			"/api/v1/categories/:slug/articles": cfg.HTTPCache.ArticlesByCategory,
+			"/api/v1/zenn/articles":             cfg.HTTPCache.ZennArticles,
+		},
+	}))
+
 	// Register OpenAPI generated routes
 	openapi.RegisterHandlers(e, di.APIHandler)
+
+	// Readiness probe of the upstreams; /health stays a liveness check
+	e.GET(readinessPath, di.ReadinessHandler.Ready)
+
+	// Runtime log level, for keys with the admin scope
+	if cfg.Log.LevelEndpointEnabled {
+		e.GET(logLevelPath, di.LogLevelHandler.Get)
+		e.PUT(logLevelPath, di.LogLevelHandler.Set)
+	}
+
+	// Page views that rank the popular articles
+	e.POST(articleViewsPath, di.ViewHandler.Record)
+
+	// Encrypted preview links to drafts, for reviewers without an API key
+	if cfg.Preview.TokenSecret != "" {
+		e.POST(previewTokensPath, di.PreviewHandler.IssueToken)
+		e.GET(previewArticlePath, di.PreviewHandler.GetArticle,
+			middleware.CountAuthFailures(di.Metrics, "preview_token", middleware.PreviewToken(di.PreviewSealer)))
+	}
+
+	// Prometheus metrics
+	if cfg.MetricsEnabled {
+		e.GET(metricsPath, echo.WrapHandler(di.Metrics.Handler()))
+	}
+
+	// microCMS webhook for cache purges
+	if cfg.MicroCMSWebhookSecret != "" {
+		e.POST(microCMSWebhookPath, di.WebhookHandler.MicroCMS,
+			middleware.CountAuthFailures(di.Metrics, "webhook_signature", middleware.MicroCMSSignature(cfg.MicroCMSWebhookSecret)))
+	}
+}
+
+func newRateLimitConfig(cfg *config.Config, store ratelimit.Store) middleware.RateLimitConfig {
+	rateLimitCfg := middleware.RateLimitConfig{
+		// Webhook bursts follow bulk edits in microCMS, and dropping one
+		// would leave stale content in the cache.
+		Skipper: func(c echo.Context) bool {
+			return operationalPaths[c.Path()] || c.Path() == microCMSWebhookPath
+		},
+		Store:  store,
+		Key:    newLimit(cfg.RateLimit.Key),
+		IP:     newLimit(cfg.RateLimit.IP),
+		Scopes: make(map[middleware.Scope]ratelimit.Limit, len(cfg.RateLimit.Scopes)),
+		Keys:   make(map[string]ratelimit.Limit),
+	}
+	for scope, limit := range cfg.RateLimit.Scopes {
+		rateLimitCfg.Scopes[middleware.Scope(scope)] = newLimit(limit)
+	}
+	for _, key := range cfg.APIKeys {
+		if key.RateLimit != nil {
+			rateLimitCfg.Keys[key.Name] = newLimit(*key.RateLimit)
+		}
+	}
+	return rateLimitCfg
+}
+
+func newLimit(limit config.RateLimit) ratelimit.Limit {
+	return ratelimit.Limit{
+		Requests: limit.Requests,
+		Period:   limit.Period,
+	}
 }
@@ -1,21 +1,77 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
 	"github.com/kozennoki/nerine/internal/infrastructure/config"
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-func setupServer(cfg *config.Config, logger *zap.Logger) *echo.Echo {
+func setupServer(cfg *config.Config, logger *zap.Logger, logLevel zap.AtomicLevel, tracer trace.Tracer) (*echo.Echo, *DIContainer, error) {
 	e := echo.New()
 
-	di := NewDIContainer(cfg)
-	setupRoutes(e, di, cfg)
+	di, err := NewDIContainer(cfg, logger, logLevel, tracer)
+	if err != nil {
+		return nil, nil, err
+	}
+	e.HTTPErrorHandler = di.ErrorRenderer.HandleError
+	e.IPExtractor = newIPExtractor(cfg.TrustedProxies)
+	setupRoutes(e, di, cfg, logger)
+
+	return e, di, nil
+}
+
+// newIPExtractor returns how c.RealIP finds the client IP for rate limits and
+// page views. X-Forwarded-For is only believed when the connection comes from
+// one of trustedProxies, since any client can send it.
+func newIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
 
-	return e
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
 }
 
-func startServer(e *echo.Echo, cfg *config.Config, logger *zap.Logger) error {
-	logger.Info("Starting server", zap.String("port", cfg.Port))
-	return e.Start(":" + cfg.Port)
+// runServer serves until ctx is cancelled and then shuts the server down
+// gracefully: the listener is closed at once and in-flight requests get
+// cfg.ShutdownTimeout to finish before their connections are closed.
+func runServer(ctx context.Context, e *echo.Echo, cfg *config.Config, logger *zap.Logger) error {
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("Starting server", zap.String("port", cfg.Port))
+		errCh <- e.Start(":" + cfg.Port)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to start server: %w", err)
+	case <-ctx.Done():
+	}
+
+	logger.Info("Shutting down server", zap.Duration("timeout", cfg.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		closeErr := e.Close()
+		return errors.Join(fmt.Errorf("failed to drain in-flight requests: %w", err), closeErr)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
 }
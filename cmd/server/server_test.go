@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/config"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// startTestServer runs e with runServer on a free port and returns its base
+// URL and the channel receiving the result of runServer.
+func startTestServer(t *testing.T, ctx context.Context, e *echo.Echo, shutdownTimeout time.Duration) (string, <-chan error) {
+	t.Helper()
+
+	e.HideBanner = true
+	e.HidePort = true
+	cfg := &config.Config{Port: "0", ShutdownTimeout: shutdownTimeout}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runServer(ctx, e, cfg, zap.NewNop())
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for e.ListenerAddr() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Server did not start listening")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return "http://" + e.ListenerAddr().String(), done
+}
+
+func TestRunServer_DrainsInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	e := echo.New()
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		return c.String(http.StatusOK, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	url, done := startTestServer(t, ctx, e, 5*time.Second)
+
+	respCh := make(chan *http.Response, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			errCh <- err
+			return
+		}
+		respCh <- resp
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case resp := <-respCh:
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status code 200, got: %d", resp.StatusCode)
+		}
+	case err := <-errCh:
+		t.Fatalf("Expected the in-flight request to complete, got: %v", err)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("Expected a clean shutdown, got: %v", err)
+	}
+}
+
+func TestRunServer_ShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	e := echo.New()
+	e.GET("/stuck", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	url, done := startTestServer(t, ctx, e, 50*time.Millisecond)
+
+	go func() {
+		resp, err := http.Get(url + "/stuck")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-done; err == nil {
+		t.Error("Expected an error when in-flight requests outlive the shutdown timeout")
+	}
+}
+
+func TestRunServer_StartError(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Port: "invalid", ShutdownTimeout: time.Second}
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	if err := runServer(context.Background(), e, cfg, zap.NewNop()); err == nil {
+		t.Error("Expected an error for an invalid port")
+	}
+}
+
+func TestNewIPExtractor(t *testing.T) {
+	t.Parallel()
+
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name           string
+		trustedProxies []*net.IPNet
+		remoteAddr     string
+		want           string
+	}{
+		{name: "no trusted proxies", remoteAddr: "198.51.100.1:1234", want: "198.51.100.1"},
+		{name: "no trusted proxies, private peer", remoteAddr: "10.0.0.2:1234", want: "10.0.0.2"},
+		{name: "untrusted peer", trustedProxies: []*net.IPNet{proxies}, remoteAddr: "198.51.100.1:1234", want: "198.51.100.1"},
+		{name: "trusted proxy", trustedProxies: []*net.IPNet{proxies}, remoteAddr: "10.0.0.2:1234", want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tt.remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7")
+		req.Header.Set(echo.HeaderXRealIP, "203.0.113.8")
+
+		if got := newIPExtractor(tt.trustedProxies)(req); got != tt.want {
+			t.Errorf("%s: expected client IP %s, got: %s", tt.name, tt.want, got)
+		}
+	}
+}
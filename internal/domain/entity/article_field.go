@@ -0,0 +1,78 @@
+package entity
+
+import "strings"
+
+// ArticleField names an Article field that can be selected in list responses.
+type ArticleField string
+
+const (
+	ArticleFieldID          ArticleField = "ID"
+	ArticleFieldTitle       ArticleField = "Title"
+	ArticleFieldImage       ArticleField = "Image"
+	ArticleFieldCategory    ArticleField = "Category"
+	ArticleFieldDescription ArticleField = "Description"
+	ArticleFieldBody        ArticleField = "Body"
+	ArticleFieldPublishedAt ArticleField = "PublishedAt"
+	ArticleFieldCreatedAt   ArticleField = "CreatedAt"
+	ArticleFieldUpdatedAt   ArticleField = "UpdatedAt"
+)
+
+// AllArticleFields lists every selectable field in response order.
+var AllArticleFields = []ArticleField{
+	ArticleFieldID,
+	ArticleFieldTitle,
+	ArticleFieldImage,
+	ArticleFieldCategory,
+	ArticleFieldDescription,
+	ArticleFieldBody,
+	ArticleFieldPublishedAt,
+	ArticleFieldCreatedAt,
+	ArticleFieldUpdatedAt,
+}
+
+// ParseArticleField returns the field matching name, ignoring case.
+func ParseArticleField(name string) (ArticleField, bool) {
+	for _, f := range AllArticleFields {
+		if strings.EqualFold(string(f), name) {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// NormalizeArticleFields removes duplicates, always includes ID and returns
+// the fields in response order, so equal selections compare equal.
+// An empty selection stays empty and means every field.
+func NormalizeArticleFields(fields []ArticleField) []ArticleField {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	selected := make(map[ArticleField]bool, len(fields)+1)
+	selected[ArticleFieldID] = true
+	for _, f := range fields {
+		selected[f] = true
+	}
+
+	result := make([]ArticleField, 0, len(selected))
+	for _, f := range AllArticleFields {
+		if selected[f] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// HasArticleField reports whether field is part of fields.
+// An empty selection includes every field.
+func HasArticleField(fields []ArticleField, field ArticleField) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
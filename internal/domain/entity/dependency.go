@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// DependencyStatus is the result of the latest readiness probe of a
+// dependency such as microCMS or Zenn.
+type DependencyStatus struct {
+	Name string
+	// Required dependencies make the server not ready while they are down.
+	Required  bool
+	Healthy   bool
+	Latency   time.Duration
+	CheckedAt time.Time
+	// LastError is the error of the most recent failed probe. It is kept
+	// after the dependency recovers, together with the time it occurred.
+	LastError   error
+	LastErrorAt time.Time
+}
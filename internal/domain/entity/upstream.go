@@ -0,0 +1,16 @@
+package entity
+
+// CircuitState is the state of the circuit breaker in front of an upstream.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitHalfOpen CircuitState = "half-open"
+	CircuitOpen     CircuitState = "open"
+)
+
+// UpstreamStatus reports whether requests to an upstream are let through.
+type UpstreamStatus struct {
+	Name  string
+	State CircuitState
+}
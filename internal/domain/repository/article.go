@@ -6,20 +6,33 @@ import (
 	"github.com/kozennoki/nerine/internal/domain/entity"
 )
 
+// ArticlePage は一覧取得の結果。Total はページングに使う総件数で、
+// 取得元が総件数を返さない場合は 0 になる。
+type ArticlePage struct {
+	Articles []*entity.Article
+	Total    int
+}
+
 // ArticleReader は一覧取得の最小インターフェース。
 // Zenn はこれだけ実装する。
+// 一覧系メソッドの fields は取得するフィールドの指定で、空なら全フィールドを返す。
+// 取得元が絞り込みに対応しない場合は無視してよい。
 type ArticleReader interface {
-	GetArticles(ctx context.Context, limit, offset int) ([]*entity.Article, error)
+	GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (ArticlePage, error)
 }
 
 // ArticleAdvancedReader は microCMS 側が提供する拡張機能。
 type ArticleAdvancedReader interface {
 	GetArticleByID(ctx context.Context, id string) (*entity.Article, error)
-	GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int) ([]*entity.Article, error)
-	GetPopularArticles(ctx context.Context, limit int) ([]*entity.Article, error)
-	GetLatestArticles(ctx context.Context, limit int) ([]*entity.Article, error)
-	CountArticles(ctx context.Context) (int, error)
-	CountArticlesByCategory(ctx context.Context, categorySlug string) (int, error)
+	// GetArticlesByIDs は ids の記事を1回の取得でまとめて ids の順に返す。
+	// 公開されていない記事は結果に含まれない。
+	GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error)
+	// GetDraftArticleByID は draftKey を使って公開前の下書きを含む記事を取得する。
+	// 結果はキャッシュしてはならない。
+	GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error)
+	GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (ArticlePage, error)
+	GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error)
+	GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error)
 }
 
 type ArticleRepository interface {
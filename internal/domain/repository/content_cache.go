@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// ContentCacheInvalidator は取得元で変更されたコンテンツのキャッシュを破棄する。
+type ContentCacheInvalidator interface {
+	// InvalidateArticle は記事と、その記事を含みうる一覧・指定カテゴリの記事一覧を破棄する。
+	// id が空の場合は全記事を対象にする。
+	InvalidateArticle(ctx context.Context, id string, categorySlugs []string) error
+	// InvalidateCategory はカテゴリを破棄する。記事はカテゴリ名を含むため、記事のキャッシュも全て破棄する。
+	InvalidateCategory(ctx context.Context, slug string) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "errors"
+
+// リポジトリが返すエラーの種類。インフラ層は上流のエラーをこれらでラップし、
+// 呼び出し側は errors.Is で判別する。
+var (
+	// ErrNotFound はリポジトリが対象のコンテンツを見つけられなかったことを表す。
+	ErrNotFound = errors.New("not found")
+	// ErrUpstreamUnavailable は上流サービスに接続できない、または上流が障害を返したことを表す。
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrRateLimited は上流サービスのレート制限に達したことを表す。
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInvalidArgument はリクエストの内容が不正で上流に受け付けられなかったことを表す。
+	ErrInvalidArgument = errors.New("invalid argument")
+)
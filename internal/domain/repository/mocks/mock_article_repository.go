@@ -14,9 +14,163 @@ import (
 	reflect "reflect"
 
 	entity "github.com/kozennoki/nerine/internal/domain/entity"
+	repository "github.com/kozennoki/nerine/internal/domain/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockArticleReader is a mock of ArticleReader interface.
+type MockArticleReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleReaderMockRecorder is the mock recorder for MockArticleReader.
+type MockArticleReaderMockRecorder struct {
+	mock *MockArticleReader
+}
+
+// NewMockArticleReader creates a new mock instance.
+func NewMockArticleReader(ctrl *gomock.Controller) *MockArticleReader {
+	mock := &MockArticleReader{ctrl: ctrl}
+	mock.recorder = &MockArticleReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleReader) EXPECT() *MockArticleReaderMockRecorder {
+	return m.recorder
+}
+
+// GetArticles mocks base method.
+func (m *MockArticleReader) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticles", ctx, limit, offset, fields)
+	ret0, _ := ret[0].(repository.ArticlePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticles indicates an expected call of GetArticles.
+func (mr *MockArticleReaderMockRecorder) GetArticles(ctx, limit, offset, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticles", reflect.TypeOf((*MockArticleReader)(nil).GetArticles), ctx, limit, offset, fields)
+}
+
+// MockArticleAdvancedReader is a mock of ArticleAdvancedReader interface.
+type MockArticleAdvancedReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleAdvancedReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleAdvancedReaderMockRecorder is the mock recorder for MockArticleAdvancedReader.
+type MockArticleAdvancedReaderMockRecorder struct {
+	mock *MockArticleAdvancedReader
+}
+
+// NewMockArticleAdvancedReader creates a new mock instance.
+func NewMockArticleAdvancedReader(ctrl *gomock.Controller) *MockArticleAdvancedReader {
+	mock := &MockArticleAdvancedReader{ctrl: ctrl}
+	mock.recorder = &MockArticleAdvancedReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleAdvancedReader) EXPECT() *MockArticleAdvancedReaderMockRecorder {
+	return m.recorder
+}
+
+// GetArticleByID mocks base method.
+func (m *MockArticleAdvancedReader) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleByID indicates an expected call of GetArticleByID.
+func (mr *MockArticleAdvancedReaderMockRecorder) GetArticleByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleByID", reflect.TypeOf((*MockArticleAdvancedReader)(nil).GetArticleByID), ctx, id)
+}
+
+// GetArticlesByCategory mocks base method.
+func (m *MockArticleAdvancedReader) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticlesByCategory", ctx, categorySlug, limit, offset, fields)
+	ret0, _ := ret[0].(repository.ArticlePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticlesByCategory indicates an expected call of GetArticlesByCategory.
+func (mr *MockArticleAdvancedReaderMockRecorder) GetArticlesByCategory(ctx, categorySlug, limit, offset, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesByCategory", reflect.TypeOf((*MockArticleAdvancedReader)(nil).GetArticlesByCategory), ctx, categorySlug, limit, offset, fields)
+}
+
+// GetArticlesByIDs mocks base method.
+func (m *MockArticleAdvancedReader) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticlesByIDs", ctx, ids, fields)
+	ret0, _ := ret[0].([]*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticlesByIDs indicates an expected call of GetArticlesByIDs.
+func (mr *MockArticleAdvancedReaderMockRecorder) GetArticlesByIDs(ctx, ids, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesByIDs", reflect.TypeOf((*MockArticleAdvancedReader)(nil).GetArticlesByIDs), ctx, ids, fields)
+}
+
+// GetDraftArticleByID mocks base method.
+func (m *MockArticleAdvancedReader) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraftArticleByID", ctx, id, draftKey)
+	ret0, _ := ret[0].(*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraftArticleByID indicates an expected call of GetDraftArticleByID.
+func (mr *MockArticleAdvancedReaderMockRecorder) GetDraftArticleByID(ctx, id, draftKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftArticleByID", reflect.TypeOf((*MockArticleAdvancedReader)(nil).GetDraftArticleByID), ctx, id, draftKey)
+}
+
+// GetLatestArticles mocks base method.
+func (m *MockArticleAdvancedReader) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestArticles", ctx, limit, fields)
+	ret0, _ := ret[0].([]*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestArticles indicates an expected call of GetLatestArticles.
+func (mr *MockArticleAdvancedReaderMockRecorder) GetLatestArticles(ctx, limit, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestArticles", reflect.TypeOf((*MockArticleAdvancedReader)(nil).GetLatestArticles), ctx, limit, fields)
+}
+
+// GetPopularArticles mocks base method.
+func (m *MockArticleAdvancedReader) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopularArticles", ctx, limit, fields)
+	ret0, _ := ret[0].([]*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopularArticles indicates an expected call of GetPopularArticles.
+func (mr *MockArticleAdvancedReaderMockRecorder) GetPopularArticles(ctx, limit, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularArticles", reflect.TypeOf((*MockArticleAdvancedReader)(nil).GetPopularArticles), ctx, limit, fields)
+}
+
 // MockArticleRepository is a mock of ArticleRepository interface.
 type MockArticleRepository struct {
 	ctrl     *gomock.Controller
@@ -41,107 +195,107 @@ func (m *MockArticleRepository) EXPECT() *MockArticleRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CountArticles mocks base method.
-func (m *MockArticleRepository) CountArticles(ctx context.Context) (int, error) {
+// GetArticleByID mocks base method.
+func (m *MockArticleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountArticles", ctx)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetArticleByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountArticles indicates an expected call of CountArticles.
-func (mr *MockArticleRepositoryMockRecorder) CountArticles(ctx any) *gomock.Call {
+// GetArticleByID indicates an expected call of GetArticleByID.
+func (mr *MockArticleRepositoryMockRecorder) GetArticleByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountArticles", reflect.TypeOf((*MockArticleRepository)(nil).CountArticles), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleByID", reflect.TypeOf((*MockArticleRepository)(nil).GetArticleByID), ctx, id)
 }
 
-// CountArticlesByCategory mocks base method.
-func (m *MockArticleRepository) CountArticlesByCategory(ctx context.Context, categorySlug string) (int, error) {
+// GetArticles mocks base method.
+func (m *MockArticleRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountArticlesByCategory", ctx, categorySlug)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetArticles", ctx, limit, offset, fields)
+	ret0, _ := ret[0].(repository.ArticlePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountArticlesByCategory indicates an expected call of CountArticlesByCategory.
-func (mr *MockArticleRepositoryMockRecorder) CountArticlesByCategory(ctx, categorySlug any) *gomock.Call {
+// GetArticles indicates an expected call of GetArticles.
+func (mr *MockArticleRepositoryMockRecorder) GetArticles(ctx, limit, offset, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountArticlesByCategory", reflect.TypeOf((*MockArticleRepository)(nil).CountArticlesByCategory), ctx, categorySlug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticles", reflect.TypeOf((*MockArticleRepository)(nil).GetArticles), ctx, limit, offset, fields)
 }
 
-// GetArticleByID mocks base method.
-func (m *MockArticleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
+// GetArticlesByCategory mocks base method.
+func (m *MockArticleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArticleByID", ctx, id)
-	ret0, _ := ret[0].(*entity.Article)
+	ret := m.ctrl.Call(m, "GetArticlesByCategory", ctx, categorySlug, limit, offset, fields)
+	ret0, _ := ret[0].(repository.ArticlePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArticleByID indicates an expected call of GetArticleByID.
-func (mr *MockArticleRepositoryMockRecorder) GetArticleByID(ctx, id any) *gomock.Call {
+// GetArticlesByCategory indicates an expected call of GetArticlesByCategory.
+func (mr *MockArticleRepositoryMockRecorder) GetArticlesByCategory(ctx, categorySlug, limit, offset, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleByID", reflect.TypeOf((*MockArticleRepository)(nil).GetArticleByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesByCategory", reflect.TypeOf((*MockArticleRepository)(nil).GetArticlesByCategory), ctx, categorySlug, limit, offset, fields)
 }
 
-// GetArticles mocks base method.
-func (m *MockArticleRepository) GetArticles(ctx context.Context, limit, offset int) ([]*entity.Article, error) {
+// GetArticlesByIDs mocks base method.
+func (m *MockArticleRepository) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArticles", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "GetArticlesByIDs", ctx, ids, fields)
 	ret0, _ := ret[0].([]*entity.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArticles indicates an expected call of GetArticles.
-func (mr *MockArticleRepositoryMockRecorder) GetArticles(ctx, limit, offset any) *gomock.Call {
+// GetArticlesByIDs indicates an expected call of GetArticlesByIDs.
+func (mr *MockArticleRepositoryMockRecorder) GetArticlesByIDs(ctx, ids, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticles", reflect.TypeOf((*MockArticleRepository)(nil).GetArticles), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesByIDs", reflect.TypeOf((*MockArticleRepository)(nil).GetArticlesByIDs), ctx, ids, fields)
 }
 
-// GetArticlesByCategory mocks base method.
-func (m *MockArticleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int) ([]*entity.Article, error) {
+// GetDraftArticleByID mocks base method.
+func (m *MockArticleRepository) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArticlesByCategory", ctx, categorySlug, limit, offset)
-	ret0, _ := ret[0].([]*entity.Article)
+	ret := m.ctrl.Call(m, "GetDraftArticleByID", ctx, id, draftKey)
+	ret0, _ := ret[0].(*entity.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArticlesByCategory indicates an expected call of GetArticlesByCategory.
-func (mr *MockArticleRepositoryMockRecorder) GetArticlesByCategory(ctx, categorySlug, limit, offset any) *gomock.Call {
+// GetDraftArticleByID indicates an expected call of GetDraftArticleByID.
+func (mr *MockArticleRepositoryMockRecorder) GetDraftArticleByID(ctx, id, draftKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesByCategory", reflect.TypeOf((*MockArticleRepository)(nil).GetArticlesByCategory), ctx, categorySlug, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftArticleByID", reflect.TypeOf((*MockArticleRepository)(nil).GetDraftArticleByID), ctx, id, draftKey)
 }
 
 // GetLatestArticles mocks base method.
-func (m *MockArticleRepository) GetLatestArticles(ctx context.Context, limit int) ([]*entity.Article, error) {
+func (m *MockArticleRepository) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestArticles", ctx, limit)
+	ret := m.ctrl.Call(m, "GetLatestArticles", ctx, limit, fields)
 	ret0, _ := ret[0].([]*entity.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLatestArticles indicates an expected call of GetLatestArticles.
-func (mr *MockArticleRepositoryMockRecorder) GetLatestArticles(ctx, limit any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) GetLatestArticles(ctx, limit, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestArticles", reflect.TypeOf((*MockArticleRepository)(nil).GetLatestArticles), ctx, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestArticles", reflect.TypeOf((*MockArticleRepository)(nil).GetLatestArticles), ctx, limit, fields)
 }
 
 // GetPopularArticles mocks base method.
-func (m *MockArticleRepository) GetPopularArticles(ctx context.Context, limit int) ([]*entity.Article, error) {
+func (m *MockArticleRepository) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPopularArticles", ctx, limit)
+	ret := m.ctrl.Call(m, "GetPopularArticles", ctx, limit, fields)
 	ret0, _ := ret[0].([]*entity.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPopularArticles indicates an expected call of GetPopularArticles.
-func (mr *MockArticleRepositoryMockRecorder) GetPopularArticles(ctx, limit any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) GetPopularArticles(ctx, limit, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularArticles", reflect.TypeOf((*MockArticleRepository)(nil).GetPopularArticles), ctx, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopularArticles", reflect.TypeOf((*MockArticleRepository)(nil).GetPopularArticles), ctx, limit, fields)
 }
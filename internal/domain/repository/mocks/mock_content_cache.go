@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/content_cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/content_cache.go -destination=internal/domain/repository/mocks/mock_content_cache.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockContentCacheInvalidator is a mock of ContentCacheInvalidator interface.
+type MockContentCacheInvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockContentCacheInvalidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockContentCacheInvalidatorMockRecorder is the mock recorder for MockContentCacheInvalidator.
+type MockContentCacheInvalidatorMockRecorder struct {
+	mock *MockContentCacheInvalidator
+}
+
+// NewMockContentCacheInvalidator creates a new mock instance.
+func NewMockContentCacheInvalidator(ctrl *gomock.Controller) *MockContentCacheInvalidator {
+	mock := &MockContentCacheInvalidator{ctrl: ctrl}
+	mock.recorder = &MockContentCacheInvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContentCacheInvalidator) EXPECT() *MockContentCacheInvalidatorMockRecorder {
+	return m.recorder
+}
+
+// InvalidateArticle mocks base method.
+func (m *MockContentCacheInvalidator) InvalidateArticle(ctx context.Context, id string, categorySlugs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateArticle", ctx, id, categorySlugs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateArticle indicates an expected call of InvalidateArticle.
+func (mr *MockContentCacheInvalidatorMockRecorder) InvalidateArticle(ctx, id, categorySlugs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateArticle", reflect.TypeOf((*MockContentCacheInvalidator)(nil).InvalidateArticle), ctx, id, categorySlugs)
+}
+
+// InvalidateCategory mocks base method.
+func (m *MockContentCacheInvalidator) InvalidateCategory(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateCategory", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateCategory indicates an expected call of InvalidateCategory.
+func (mr *MockContentCacheInvalidatorMockRecorder) InvalidateCategory(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateCategory", reflect.TypeOf((*MockContentCacheInvalidator)(nil).InvalidateCategory), ctx, slug)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/popularity.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/popularity.go -destination=internal/domain/repository/mocks/mock_popularity_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPopularityRepository is a mock of PopularityRepository interface.
+type MockPopularityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPopularityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPopularityRepositoryMockRecorder is the mock recorder for MockPopularityRepository.
+type MockPopularityRepositoryMockRecorder struct {
+	mock *MockPopularityRepository
+}
+
+// NewMockPopularityRepository creates a new mock instance.
+func NewMockPopularityRepository(ctrl *gomock.Controller) *MockPopularityRepository {
+	mock := &MockPopularityRepository{ctrl: ctrl}
+	mock.recorder = &MockPopularityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPopularityRepository) EXPECT() *MockPopularityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// RankArticleIDs mocks base method.
+func (m *MockPopularityRepository) RankArticleIDs(ctx context.Context, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RankArticleIDs", ctx, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RankArticleIDs indicates an expected call of RankArticleIDs.
+func (mr *MockPopularityRepositoryMockRecorder) RankArticleIDs(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RankArticleIDs", reflect.TypeOf((*MockPopularityRepository)(nil).RankArticleIDs), ctx, limit)
+}
+
+// RecordView mocks base method.
+func (m *MockPopularityRepository) RecordView(ctx context.Context, articleID, visitor string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordView", ctx, articleID, visitor)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordView indicates an expected call of RecordView.
+func (mr *MockPopularityRepositoryMockRecorder) RecordView(ctx, articleID, visitor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordView", reflect.TypeOf((*MockPopularityRepository)(nil).RecordView), ctx, articleID, visitor)
+}
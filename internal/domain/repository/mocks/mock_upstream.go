@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/upstream.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/upstream.go -destination=internal/domain/repository/mocks/mock_upstream.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/kozennoki/nerine/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUpstreamMonitor is a mock of UpstreamMonitor interface.
+type MockUpstreamMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpstreamMonitorMockRecorder
+	isgomock struct{}
+}
+
+// MockUpstreamMonitorMockRecorder is the mock recorder for MockUpstreamMonitor.
+type MockUpstreamMonitorMockRecorder struct {
+	mock *MockUpstreamMonitor
+}
+
+// NewMockUpstreamMonitor creates a new mock instance.
+func NewMockUpstreamMonitor(ctrl *gomock.Controller) *MockUpstreamMonitor {
+	mock := &MockUpstreamMonitor{ctrl: ctrl}
+	mock.recorder = &MockUpstreamMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpstreamMonitor) EXPECT() *MockUpstreamMonitorMockRecorder {
+	return m.recorder
+}
+
+// GetUpstreamStatuses mocks base method.
+func (m *MockUpstreamMonitor) GetUpstreamStatuses(ctx context.Context) ([]*entity.UpstreamStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamStatuses", ctx)
+	ret0, _ := ret[0].([]*entity.UpstreamStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpstreamStatuses indicates an expected call of GetUpstreamStatuses.
+func (mr *MockUpstreamMonitorMockRecorder) GetUpstreamStatuses(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamStatuses", reflect.TypeOf((*MockUpstreamMonitor)(nil).GetUpstreamStatuses), ctx)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// PopularityRepository は記事の閲覧数を記録し、人気順の記事IDを返す。
+type PopularityRepository interface {
+	// RecordView は visitor による articleID の閲覧を記録する。
+	// 同じ visitor の一定時間内の閲覧は数えず、false を返す。
+	RecordView(ctx context.Context, articleID, visitor string) (bool, error)
+	// RankArticleIDs は人気の高い順に最大 limit 件の記事IDを返す。
+	RankArticleIDs(ctx context.Context, limit int) ([]string, error)
+}
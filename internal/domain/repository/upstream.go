@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+)
+
+// UpstreamMonitor は上流サービス（microCMS・Zenn）への接続状態を返す。
+type UpstreamMonitor interface {
+	// GetUpstreamStatuses は上流サービスごとのサーキットブレーカーの状態を返す。
+	GetUpstreamStatuses(ctx context.Context) ([]*entity.UpstreamStatus, error)
+}
@@ -0,0 +1,96 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/sony/gobreaker/v2"
+	"go.uber.org/zap"
+)
+
+// Settings controls when a breaker opens and how long it stays open.
+type Settings struct {
+	// FailureRatio of the requests in Interval that opens the breaker once
+	// at least MinRequests were made.
+	FailureRatio float64
+	MinRequests  uint32
+	// Interval is the window over which failures are counted while closed.
+	Interval time.Duration
+	// Cooldown is how long the breaker stays open before letting
+	// HalfOpenRequests trial requests through.
+	Cooldown         time.Duration
+	HalfOpenRequests uint32
+}
+
+// Breaker fails calls to one upstream fast while it keeps failing.
+// Only ErrUpstreamUnavailable and ErrRateLimited count as failures; missing
+// content or invalid arguments say nothing about the upstream's health.
+type Breaker struct {
+	cb *gobreaker.TwoStepCircuitBreaker[struct{}]
+}
+
+func New(name string, settings Settings, logger *zap.Logger) *Breaker {
+	return &Breaker{
+		cb: gobreaker.NewTwoStepCircuitBreaker[struct{}](gobreaker.Settings{
+			Name:        name,
+			MaxRequests: settings.HalfOpenRequests,
+			Interval:    settings.Interval,
+			Timeout:     settings.Cooldown,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				if counts.Requests < settings.MinRequests {
+					return false
+				}
+				return float64(counts.TotalFailures)/float64(counts.Requests) >= settings.FailureRatio
+			},
+			OnStateChange: func(name string, from, to gobreaker.State) {
+				logger.Warn("Circuit breaker state changed",
+					zap.String("upstream", name),
+					zap.Stringer("from", from),
+					zap.Stringer("to", to),
+				)
+			},
+			IsSuccessful: func(err error) bool {
+				return !isFailure(err)
+			},
+			IsExcluded: func(err error) bool {
+				return errors.Is(err, context.Canceled)
+			},
+		}),
+	}
+}
+
+func (b *Breaker) Name() string {
+	return b.cb.Name()
+}
+
+func (b *Breaker) State() entity.CircuitState {
+	switch b.cb.State() {
+	case gobreaker.StateOpen:
+		return entity.CircuitOpen
+	case gobreaker.StateHalfOpen:
+		return entity.CircuitHalfOpen
+	default:
+		return entity.CircuitClosed
+	}
+}
+
+func isFailure(err error) bool {
+	return errors.Is(err, repository.ErrUpstreamUnavailable) || errors.Is(err, repository.ErrRateLimited)
+}
+
+// call runs fn if the breaker lets the request through and records its outcome.
+func call[T any](ctx context.Context, b *Breaker, fn func(ctx context.Context) (T, error)) (T, error) {
+	done, err := b.cb.Allow()
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("%s: %w: %w", b.Name(), repository.ErrUpstreamUnavailable, err)
+	}
+
+	result, err := fn(ctx)
+	done(err)
+	return result, err
+}
@@ -0,0 +1,144 @@
+package breaker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/breaker"
+	"github.com/sony/gobreaker/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+var testSettings = breaker.Settings{
+	FailureRatio:     0.5,
+	MinRequests:      4,
+	Interval:         time.Minute,
+	Cooldown:         50 * time.Millisecond,
+	HalfOpenRequests: 1,
+}
+
+var errUpstream = fmt.Errorf("zenn API returned status 502: %w", repository.ErrUpstreamUnavailable)
+
+func TestBreaker_OpensOnFailureRatio(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+
+	page := repository.ArticlePage{Articles: []*entity.Article{{ID: "zenn-article"}}}
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(page, nil),
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, errUpstream),
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(page, nil),
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, errUpstream),
+	)
+
+	b := breaker.New("zenn", testSettings, zap.NewNop())
+	repo := breaker.NewZennRepository(mockRepo, b)
+
+	for i := 0; i < 4; i++ {
+		_, _ = repo.GetArticles(context.Background(), 10, 0, nil)
+	}
+	assert.Equal(t, entity.CircuitOpen, b.State())
+
+	// The upstream is not called while the circuit is open.
+	_, err := repo.GetArticles(context.Background(), 10, 0, nil)
+	assert.ErrorIs(t, err, repository.ErrUpstreamUnavailable)
+	assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+}
+
+func TestBreaker_StaysClosedBelowMinRequests(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, errUpstream).Times(3)
+
+	b := breaker.New("zenn", testSettings, zap.NewNop())
+	repo := breaker.NewZennRepository(mockRepo, b)
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.GetArticles(context.Background(), 10, 0, nil)
+		assert.ErrorIs(t, err, errUpstream)
+	}
+	assert.Equal(t, entity.CircuitClosed, b.State())
+}
+
+func TestBreaker_IgnoresNonUpstreamErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "missing").Return(nil, repository.ErrNotFound).Times(5)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "canceled").Return(nil, context.Canceled).Times(5)
+
+	b := breaker.New("microcms", testSettings, zap.NewNop())
+	repo := breaker.NewArticleRepository(mockRepo, b)
+
+	for i := 0; i < 5; i++ {
+		_, err := repo.GetArticleByID(context.Background(), "missing")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		_, err = repo.GetArticleByID(context.Background(), "canceled")
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Equal(t, entity.CircuitClosed, b.State())
+}
+
+func TestBreaker_ClosesAfterSuccessfulTrial(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+
+	categories := []*entity.Category{{Slug: "go", Name: "Go"}}
+	gomock.InOrder(
+		mockRepo.EXPECT().GetCategories(gomock.Any()).Return(nil, errUpstream).Times(4),
+		mockRepo.EXPECT().GetCategories(gomock.Any()).Return(categories, nil),
+	)
+
+	b := breaker.New("microcms", testSettings, zap.NewNop())
+	repo := breaker.NewCategoryRepository(mockRepo, b)
+
+	for i := 0; i < 4; i++ {
+		_, _ = repo.GetCategories(context.Background())
+	}
+	require.Equal(t, entity.CircuitOpen, b.State())
+
+	time.Sleep(testSettings.Cooldown * 2)
+	assert.Equal(t, entity.CircuitHalfOpen, b.State())
+
+	got, err := repo.GetCategories(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, categories, got)
+	assert.Equal(t, entity.CircuitClosed, b.State())
+}
+
+func TestMonitor_GetUpstreamStatuses(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, errUpstream).Times(4)
+
+	microCMSBreaker := breaker.New("microcms", testSettings, zap.NewNop())
+	zennBreaker := breaker.New("zenn", testSettings, zap.NewNop())
+	repo := breaker.NewZennRepository(mockRepo, zennBreaker)
+	for i := 0; i < 4; i++ {
+		_, _ = repo.GetArticles(context.Background(), 10, 0, nil)
+	}
+
+	statuses, err := breaker.NewMonitor(microCMSBreaker, zennBreaker).GetUpstreamStatuses(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.UpstreamStatus{
+		{Name: "microcms", State: entity.CircuitClosed},
+		{Name: "zenn", State: entity.CircuitOpen},
+	}, statuses)
+}
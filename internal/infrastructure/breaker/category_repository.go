@@ -0,0 +1,36 @@
+package breaker
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type categoryRepository struct {
+	next    repository.CategoryRepository
+	breaker *Breaker
+}
+
+// NewCategoryRepository guards every call to next with breaker.
+func NewCategoryRepository(
+	next repository.CategoryRepository,
+	breaker *Breaker,
+) repository.CategoryRepository {
+	return &categoryRepository{
+		next:    next,
+		breaker: breaker,
+	}
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context) ([]*entity.Category, error) {
+	return call(ctx, r.breaker, func(ctx context.Context) ([]*entity.Category, error) {
+		return r.next.GetCategories(ctx)
+	})
+}
+
+func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	return call(ctx, r.breaker, func(ctx context.Context) (*entity.Category, error) {
+		return r.next.GetCategoryBySlug(ctx, slug)
+	})
+}
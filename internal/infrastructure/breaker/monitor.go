@@ -0,0 +1,30 @@
+package breaker
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type monitor struct {
+	breakers []*Breaker
+}
+
+// NewMonitor reports the state of breakers in the given order.
+func NewMonitor(breakers ...*Breaker) repository.UpstreamMonitor {
+	return &monitor{
+		breakers: breakers,
+	}
+}
+
+func (m *monitor) GetUpstreamStatuses(ctx context.Context) ([]*entity.UpstreamStatus, error) {
+	statuses := make([]*entity.UpstreamStatus, len(m.breakers))
+	for i, b := range m.breakers {
+		statuses[i] = &entity.UpstreamStatus{
+			Name:  b.Name(),
+			State: b.State(),
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,30 @@
+package breaker
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type zennRepository struct {
+	next    repository.ArticleReader
+	breaker *Breaker
+}
+
+// NewZennRepository guards the Zenn article source with breaker.
+func NewZennRepository(
+	next repository.ArticleReader,
+	breaker *Breaker,
+) repository.ArticleReader {
+	return &zennRepository{
+		next:    next,
+		breaker: breaker,
+	}
+}
+
+func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return call(ctx, r.breaker, func(ctx context.Context) (repository.ArticlePage, error) {
+		return r.next.GetArticles(ctx, limit, offset, fields)
+	})
+}
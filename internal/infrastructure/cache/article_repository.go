@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+const (
+	keyArticles           = "articles:"
+	keyArticle            = "article:"
+	keyArticlesByCategory = "category_articles:"
+	keyPopularArticles    = "popular_articles:"
+	keyLatestArticles     = "latest_articles:"
+)
+
+type articleRepository struct {
+	next  repository.ArticleRepository
+	store *Store
+	opts  Options
+}
+
+// NewArticleRepository wraps next with a read-through cache backed by store.
+func NewArticleRepository(
+	next repository.ArticleRepository,
+	store *Store,
+	opts Options,
+) repository.ArticleRepository {
+	return &articleRepository{
+		next:  next,
+		store: store,
+		opts:  opts,
+	}
+}
+
+func (r *articleRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	key := fmt.Sprintf("%s%d:%d:%s", keyArticles, limit, offset, fieldsKey(fields))
+	return load(ctx, r.store, key, r.opts.TTL.Articles, r.opts,
+		func(ctx context.Context) (repository.ArticlePage, error) {
+			return r.next.GetArticles(ctx, limit, offset, fields)
+		})
+}
+
+func (r *articleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
+	key := keyArticle + id
+	return load(ctx, r.store, key, r.opts.TTL.Article, r.opts,
+		func(ctx context.Context) (*entity.Article, error) {
+			return r.next.GetArticleByID(ctx, id)
+		})
+}
+
+// GetArticlesByIDs is not cached: it reads the ranked popular articles below
+// the cache, and their list is cached as a whole by GetPopularArticles.
+func (r *articleRepository) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return r.next.GetArticlesByIDs(ctx, ids, fields)
+}
+
+// GetDraftArticleByID is never cached: drafts change with every save and are
+// only visible to preview credentials.
+func (r *articleRepository) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
+	return r.next.GetDraftArticleByID(ctx, id, draftKey)
+}
+
+func (r *articleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	key := fmt.Sprintf("%s%s:%d:%d:%s", keyArticlesByCategory, categorySlug, limit, offset, fieldsKey(fields))
+	return load(ctx, r.store, key, r.opts.TTL.ArticlesByCategory, r.opts,
+		func(ctx context.Context) (repository.ArticlePage, error) {
+			return r.next.GetArticlesByCategory(ctx, categorySlug, limit, offset, fields)
+		})
+}
+
+func (r *articleRepository) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	key := fmt.Sprintf("%s%d:%s", keyPopularArticles, limit, fieldsKey(fields))
+	return load(ctx, r.store, key, r.opts.TTL.PopularArticles, r.opts,
+		func(ctx context.Context) ([]*entity.Article, error) {
+			return r.next.GetPopularArticles(ctx, limit, fields)
+		})
+}
+
+func (r *articleRepository) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	key := fmt.Sprintf("%s%d:%s", keyLatestArticles, limit, fieldsKey(fields))
+	return load(ctx, r.store, key, r.opts.TTL.LatestArticles, r.opts,
+		func(ctx context.Context) ([]*entity.Article, error) {
+			return r.next.GetLatestArticles(ctx, limit, fields)
+		})
+}
@@ -0,0 +1,193 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testOptions = cache.Options{
+	NegativeTTL: time.Minute,
+	TTL: cache.TTL{
+		Articles:           time.Minute,
+		Article:            time.Minute,
+		ArticlesByCategory: time.Minute,
+		PopularArticles:    time.Minute,
+		LatestArticles:     time.Minute,
+		Categories:         time.Minute,
+		Category:           time.Minute,
+	},
+}
+
+func TestArticleRepository_GetArticles_CachesResult(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	page := repository.ArticlePage{Articles: []*entity.Article{{ID: "1"}, {ID: "2"}}, Total: 11}
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(page, nil).Times(1)
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 10, gomock.Any()).Return(repository.ArticlePage{Articles: page.Articles[:1], Total: 11}, nil).Times(1)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 3; i++ {
+		got, err := repo.GetArticles(context.Background(), 10, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, page, got)
+	}
+
+	got, err := repo.GetArticles(context.Background(), 10, 10, nil)
+	require.NoError(t, err)
+	assert.Len(t, got.Articles, 1)
+	assert.Equal(t, 11, got.Total)
+}
+
+func TestArticleRepository_ErrorsAreNotCached(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	upstreamErr := errors.New("upstream error")
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, upstreamErr),
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{Total: 42}, nil),
+	)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	_, err := repo.GetArticles(context.Background(), 10, 0, nil)
+	assert.ErrorIs(t, err, upstreamErr)
+
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 42, page.Total)
+
+	page, err = repo.GetArticles(context.Background(), 10, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 42, page.Total)
+}
+
+func TestArticleRepository_GetArticleByID_NegativeCache(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	notFound := fmt.Errorf("failed to get article by ID: %w", repository.ErrNotFound)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "missing").Return(nil, notFound).Times(1)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 2; i++ {
+		article, err := repo.GetArticleByID(context.Background(), "missing")
+		assert.Nil(t, article)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	}
+}
+
+func TestArticleRepository_GetDraftArticleByID_IsNotCached(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1", Title: "Published"}, nil).Times(1)
+	mockRepo.EXPECT().GetDraftArticleByID(gomock.Any(), "1", "draft").Return(&entity.Article{ID: "1", Title: "Draft"}, nil).Times(2)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 2; i++ {
+		article, err := repo.GetDraftArticleByID(context.Background(), "1", "draft")
+		require.NoError(t, err)
+		assert.Equal(t, "Draft", article.Title)
+	}
+
+	// The draft must neither come from nor end up in the published entry.
+	for i := 0; i < 2; i++ {
+		article, err := repo.GetArticleByID(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, "Published", article.Title)
+	}
+}
+
+func TestArticleRepository_ZeroTTLBypassesCache(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 5, gomock.Any()).Return([]*entity.Article{}, nil).Times(2)
+
+	opts := testOptions
+	opts.TTL.LatestArticles = 0
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), opts)
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.GetLatestArticles(context.Background(), 5, nil)
+		require.NoError(t, err)
+	}
+}
+
+func TestArticleRepository_FieldsArePartOfTheKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	titleOnly := []entity.ArticleField{entity.ArticleFieldID, entity.ArticleFieldTitle}
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 5, nil).Return([]*entity.Article{{ID: "1", Body: "body"}}, nil).Times(1)
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 5, titleOnly).Return([]*entity.Article{{ID: "1"}}, nil).Times(1)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.GetLatestArticles(context.Background(), 5, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "body", got[0].Body)
+
+		got, err = repo.GetLatestArticles(context.Background(), 5, titleOnly)
+		require.NoError(t, err)
+		assert.Empty(t, got[0].Body)
+	}
+}
+
+func TestArticleRepository_KeysDoNotCollide(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	mockRepo.EXPECT().GetArticlesByCategory(gomock.Any(), "go", 10, 0, gomock.Any()).
+		Return(repository.ArticlePage{Articles: []*entity.Article{{ID: "go"}}, Total: 1}, nil).Times(1)
+	mockRepo.EXPECT().GetArticlesByCategory(gomock.Any(), "golang", 10, 0, gomock.Any()).
+		Return(repository.ArticlePage{Articles: []*entity.Article{{ID: "golang"}}, Total: 1}, nil).Times(1)
+	mockRepo.EXPECT().GetPopularArticles(gomock.Any(), 5, gomock.Any()).Return([]*entity.Article{{ID: "popular"}}, nil).Times(1)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 2; i++ {
+		page, err := repo.GetArticlesByCategory(context.Background(), "go", 10, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "go", page.Articles[0].ID)
+
+		page, err = repo.GetArticlesByCategory(context.Background(), "golang", 10, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "golang", page.Articles[0].ID)
+
+		got, err := repo.GetPopularArticles(context.Background(), 5, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "popular", got[0].ID)
+	}
+}
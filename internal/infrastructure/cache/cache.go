@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+const defaultRevalidateTimeout = 10 * time.Second
+
+// TTL holds the cache lifetime for each repository method.
+// A zero value disables caching for that method.
+type TTL struct {
+	Articles           time.Duration
+	Article            time.Duration
+	ArticlesByCategory time.Duration
+	PopularArticles    time.Duration
+	LatestArticles     time.Duration
+	Categories         time.Duration
+	Category           time.Duration
+	ZennArticles       time.Duration
+}
+
+type Options struct {
+	TTL TTL
+	// NegativeTTL is how long a not-found result for a single item is remembered.
+	NegativeTTL time.Duration
+	// StaleWhileRevalidate is how long after expiry an entry is still served
+	// immediately while it is refreshed in the background.
+	StaleWhileRevalidate time.Duration
+	// StaleIfError is how long after expiry an entry is kept as a fallback
+	// for when the upstream fails.
+	StaleIfError time.Duration
+	// RevalidateTimeout bounds each background refresh.
+	RevalidateTimeout time.Duration
+}
+
+func (o Options) lifetime(ttl time.Duration) Lifetime {
+	return Lifetime{
+		TTL:                  ttl,
+		StaleWhileRevalidate: o.StaleWhileRevalidate,
+		StaleIfError:         o.StaleIfError,
+	}
+}
+
+func (o Options) revalidateTimeout() time.Duration {
+	if o.RevalidateTimeout <= 0 {
+		return defaultRevalidateTimeout
+	}
+	return o.RevalidateTimeout
+}
+
+// fieldsKey encodes a field selection for use in a cache key.
+// Selections are expected to be normalized, so equal selections share a key.
+func fieldsKey(fields []entity.ArticleField) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ",")
+}
+
+// load returns the cached result for key, or calls fn and caches its result.
+// Only successful results and repository.ErrNotFound are cached. Expired
+// results are served while being refreshed in the background, and are used
+// as a fallback when fn fails.
+func load[T any](
+	ctx context.Context,
+	store *Store,
+	key string,
+	ttl time.Duration,
+	opts Options,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	var zero T
+	if ttl <= 0 {
+		return call(ctx, store, key, fn)
+	}
+
+	status := statusFromContext(ctx)
+
+	cached, state := store.Lookup(key)
+	switch state {
+	case StateFresh:
+		status.hit()
+		if cached.Err != nil {
+			return zero, cached.Err
+		}
+		return cached.Value.(T), nil
+	case StateStale:
+		store.revalidate(key, opts.revalidateTimeout(), func(ctx context.Context) {
+			fetch(ctx, store, key, ttl, opts, fn)
+		})
+		status.servedStale(cached.Age)
+		return cached.Value.(T), nil
+	}
+
+	value, err := fetch(ctx, store, key, ttl, opts, fn)
+	if err != nil {
+		if state == StateExpired && cached.Err == nil && !errors.Is(err, repository.ErrNotFound) {
+			store.recordStaleServed()
+			status.servedStale(cached.Age)
+			return cached.Value.(T), nil
+		}
+		return zero, err
+	}
+
+	status.miss()
+	return value, nil
+}
+
+// fetch calls fn and stores its result. Concurrent fetches of the same key
+// share a single upstream call, which also stores the result only once.
+// The result is not stored if the store was invalidated while fn ran.
+func fetch[T any](
+	ctx context.Context,
+	store *Store,
+	key string,
+	ttl time.Duration,
+	opts Options,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	return call(ctx, store, key, func(ctx context.Context) (T, error) {
+		gen := store.currentGeneration()
+		value, err := fn(ctx)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				store.setIfGeneration(key, Entry{Err: err}, Lifetime{TTL: opts.NegativeTTL}, gen)
+			}
+			return value, err
+		}
+
+		store.setIfGeneration(key, Entry{Value: value}, opts.lifetime(ttl), gen)
+		return value, nil
+	})
+}
+
+// call runs fn, sharing a single upstream call between concurrent callers of key.
+func call[T any](
+	ctx context.Context,
+	store *Store,
+	key string,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	var zero T
+	value, err := store.coalesce(ctx, key, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	if err != nil {
+		return zero, err
+	}
+	return value.(T), nil
+}
@@ -0,0 +1,174 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var staleOptions = cache.Options{
+	NegativeTTL:          time.Minute,
+	StaleWhileRevalidate: time.Minute,
+	StaleIfError:         time.Hour,
+	RevalidateTimeout:    time.Second,
+	TTL: cache.TTL{
+		Article: time.Minute,
+	},
+}
+
+func newStaleTestStore() (*cache.Store, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := cache.NewStore(10)
+	store.SetNow(func() time.Time { return now })
+	return store, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLoad_StaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1", Title: "old"}, nil),
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1", Title: "new"}, nil),
+	)
+
+	store, advance := newStaleTestStore()
+	repo := cache.NewArticleRepository(mockRepo, store, staleOptions)
+
+	_, err := repo.GetArticleByID(context.Background(), "1")
+	require.NoError(t, err)
+
+	advance(90 * time.Second)
+
+	ctx, status := cache.WithStatus(context.Background())
+	article, err := repo.GetArticleByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "old", article.Title)
+	assert.Equal(t, cache.ResultStale, status.Result())
+	assert.Equal(t, 90*time.Second, status.StaleAge())
+
+	store.WaitRevalidations()
+
+	ctx, status = cache.WithStatus(context.Background())
+	article, err = repo.GetArticleByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "new", article.Title)
+	assert.Equal(t, cache.ResultHit, status.Result())
+}
+
+func TestLoad_StaleIfError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	upstreamErr := errors.New("microCMS is down")
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1"}, nil),
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(nil, upstreamErr),
+	)
+
+	store, advance := newStaleTestStore()
+	repo := cache.NewArticleRepository(mockRepo, store, staleOptions)
+
+	_, err := repo.GetArticleByID(context.Background(), "1")
+	require.NoError(t, err)
+
+	advance(30 * time.Minute)
+
+	ctx, status := cache.WithStatus(context.Background())
+	article, err := repo.GetArticleByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "1", article.ID)
+	assert.Equal(t, cache.ResultStale, status.Result())
+	assert.Equal(t, 30*time.Minute, status.StaleAge())
+	assert.Equal(t, uint64(1), store.Stats().StaleServed)
+}
+
+func TestLoad_StaleIfErrorExpires(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	upstreamErr := errors.New("microCMS is down")
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1"}, nil),
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(nil, upstreamErr),
+	)
+
+	store, advance := newStaleTestStore()
+	repo := cache.NewArticleRepository(mockRepo, store, staleOptions)
+
+	_, err := repo.GetArticleByID(context.Background(), "1")
+	require.NoError(t, err)
+
+	advance(2 * time.Hour)
+
+	_, err = repo.GetArticleByID(context.Background(), "1")
+	assert.ErrorIs(t, err, upstreamErr)
+}
+
+func TestLoad_NotFoundIsNotMaskedByStaleContent(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1"}, nil),
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(nil, repository.ErrNotFound),
+	)
+
+	store, advance := newStaleTestStore()
+	repo := cache.NewArticleRepository(mockRepo, store, staleOptions)
+
+	_, err := repo.GetArticleByID(context.Background(), "1")
+	require.NoError(t, err)
+
+	advance(30 * time.Minute)
+
+	_, err = repo.GetArticleByID(context.Background(), "1")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// The deletion is remembered as a negative entry
+	_, err = repo.GetArticleByID(context.Background(), "1")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestStatus_Result(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").Return(&entity.Article{ID: "1"}, nil).Times(1)
+
+	repo := cache.NewArticleRepository(mockRepo, cache.NewStore(10), staleOptions)
+
+	ctx, status := cache.WithStatus(context.Background())
+	assert.Equal(t, "", status.Result())
+
+	_, err := repo.GetArticleByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, cache.ResultMiss, status.Result())
+
+	ctx, status = cache.WithStatus(context.Background())
+	_, err = repo.GetArticleByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, cache.ResultHit, status.Result())
+
+	var nilStatus *cache.Status
+	assert.Equal(t, "", nilStatus.Result())
+	assert.Equal(t, time.Duration(0), nilStatus.StaleAge())
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+const (
+	keyCategories = "categories:"
+	keyCategory   = "category:"
+)
+
+type categoryRepository struct {
+	next  repository.CategoryRepository
+	store *Store
+	opts  Options
+}
+
+// NewCategoryRepository wraps next with a read-through cache backed by store.
+func NewCategoryRepository(
+	next repository.CategoryRepository,
+	store *Store,
+	opts Options,
+) repository.CategoryRepository {
+	return &categoryRepository{
+		next:  next,
+		store: store,
+		opts:  opts,
+	}
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context) ([]*entity.Category, error) {
+	return load(ctx, r.store, keyCategories, r.opts.TTL.Categories, r.opts,
+		func(ctx context.Context) ([]*entity.Category, error) {
+			return r.next.GetCategories(ctx)
+		})
+}
+
+func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	return load(ctx, r.store, keyCategory+slug, r.opts.TTL.Category, r.opts,
+		func(ctx context.Context) (*entity.Category, error) {
+			return r.next.GetCategoryBySlug(ctx, slug)
+		})
+}
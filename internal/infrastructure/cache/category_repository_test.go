@@ -0,0 +1,53 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategoryRepository_GetCategories_CachesResult(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+
+	categories := []*entity.Category{{Slug: "tech", Name: "技術"}}
+	mockRepo.EXPECT().GetCategories(gomock.Any()).Return(categories, nil).Times(1)
+
+	repo := cache.NewCategoryRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.GetCategories(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, categories, got)
+	}
+}
+
+func TestCategoryRepository_GetCategoryBySlug(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+
+	mockRepo.EXPECT().GetCategoryBySlug(gomock.Any(), "tech").Return(&entity.Category{Slug: "tech"}, nil).Times(1)
+	mockRepo.EXPECT().GetCategoryBySlug(gomock.Any(), "missing").Return(nil, repository.ErrNotFound).Times(1)
+
+	repo := cache.NewCategoryRepository(mockRepo, cache.NewStore(10), testOptions)
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.GetCategoryBySlug(context.Background(), "tech")
+		require.NoError(t, err)
+		assert.Equal(t, "tech", got.Slug)
+
+		_, err = repo.GetCategoryBySlug(context.Background(), "missing")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	}
+}
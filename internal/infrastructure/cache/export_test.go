@@ -0,0 +1,13 @@
+package cache
+
+import "time"
+
+// Export private fields for testing
+func (s *Store) SetNow(now func() time.Time) {
+	s.now = now
+}
+
+// WaitRevalidations blocks until background revalidations have finished.
+func (s *Store) WaitRevalidations() {
+	s.wg.Wait()
+}
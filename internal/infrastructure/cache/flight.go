@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// flightGroup deduplicates concurrent calls with the same key.
+// Unlike a plain singleflight, the shared call runs on a context detached from
+// any single caller: each caller stops waiting when its own context is done,
+// and the upstream call is only cancelled once every caller has gone.
+type flightGroup struct {
+	mu     sync.Mutex
+	calls  map[string]*flightCall
+	merged atomic.Uint64
+}
+
+type flightCall struct {
+	done    chan struct{}
+	value   any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{
+		calls: make(map[string]*flightCall),
+	}
+}
+
+// do runs fn once for all concurrent callers of key.
+func (g *flightGroup) do(
+	ctx context.Context,
+	key string,
+	fn func(context.Context) (any, error),
+) (any, error) {
+	g.mu.Lock()
+	c, ok := g.calls[key]
+	if ok {
+		c.waiters++
+		g.merged.Add(1)
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &flightCall{
+			done:    make(chan struct{}),
+			waiters: 1,
+			cancel:  cancel,
+		}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		g.leave(key, c)
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, c *flightCall, fn func(context.Context) (any, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("panic in upstream call %s: %v", key, r)
+		}
+
+		g.mu.Lock()
+		if g.calls[key] == c {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+
+		c.cancel()
+		close(c.done)
+	}()
+
+	c.value, c.err = fn(ctx)
+}
+
+// leave drops a waiter and cancels the call when nobody is waiting any more.
+func (g *flightGroup) leave(key string, c *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	c.cancel()
+}
@@ -0,0 +1,172 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// waitForCoalesced polls until n callers have joined the in-flight call.
+func waitForCoalesced(t *testing.T, store *cache.Store, n uint64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return store.Stats().Coalesced >= n
+	}, time.Second, time.Millisecond)
+}
+
+func TestCoalescing_SharesResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		err  error
+	}{
+		{name: "cached method", ttl: time.Minute},
+		{name: "uncached method", ttl: 0},
+		{name: "error is shared", ttl: time.Minute, err: errors.New("upstream error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			const callers = 5
+
+			ctrl := gomock.NewController(t)
+			mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+			release := make(chan struct{})
+			mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+					<-release
+					if tt.err != nil {
+						return repository.ArticlePage{}, tt.err
+					}
+					return repository.ArticlePage{Articles: []*entity.Article{{ID: "1"}}, Total: 1}, nil
+				}).
+				Times(1)
+
+			store := cache.NewStore(10)
+			repo := cache.NewArticleRepository(mockRepo, store, cache.Options{
+				TTL: cache.TTL{Articles: tt.ttl},
+			})
+
+			var wg sync.WaitGroup
+			results := make([]repository.ArticlePage, callers)
+			errs := make([]error, callers)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results[i], errs[i] = repo.GetArticles(context.Background(), 10, 0, nil)
+				}(i)
+			}
+
+			waitForCoalesced(t, store, callers-1)
+			close(release)
+			wg.Wait()
+
+			for i := 0; i < callers; i++ {
+				if tt.err != nil {
+					assert.ErrorIs(t, errs[i], tt.err)
+					continue
+				}
+				require.NoError(t, errs[i])
+				assert.Equal(t, "1", results[i].Articles[0].ID)
+			}
+			assert.Equal(t, uint64(callers-1), store.Stats().Coalesced)
+		})
+	}
+}
+
+func TestCoalescing_CallerCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	release := make(chan struct{})
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "1").
+		DoAndReturn(func(ctx context.Context, id string) (*entity.Article, error) {
+			select {
+			case <-release:
+				return &entity.Article{ID: id}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}).
+		Times(1)
+
+	store := cache.NewStore(10)
+	repo := cache.NewArticleRepository(mockRepo, store, cache.Options{
+		TTL: cache.TTL{Article: time.Minute},
+	})
+
+	// The first caller gives up while the second keeps waiting
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := repo.GetArticleByID(firstCtx, "1")
+		firstErr <- err
+	}()
+
+	secondResult := make(chan *entity.Article, 1)
+	go func() {
+		article, _ := repo.GetArticleByID(context.Background(), "1")
+		secondResult <- article
+	}()
+
+	waitForCoalesced(t, store, 1)
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	article := <-secondResult
+	require.NotNil(t, article)
+	assert.Equal(t, "1", article.ID)
+}
+
+func TestCoalescing_CancelsUpstreamWhenAllCallersLeave(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	upstreamCancelled := make(chan struct{})
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 5, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+			<-ctx.Done()
+			close(upstreamCancelled)
+			return nil, ctx.Err()
+		}).
+		Times(1)
+
+	store := cache.NewStore(10)
+	repo := cache.NewArticleRepository(mockRepo, store, cache.Options{
+		TTL: cache.TTL{LatestArticles: time.Minute},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := repo.GetLatestArticles(ctx, 5, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case <-upstreamCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected upstream call to be cancelled")
+	}
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type invalidator struct {
+	store *Store
+}
+
+// NewInvalidator removes the entries cached by the repository decorators
+// that are affected by a content change.
+func NewInvalidator(store *Store) repository.ContentCacheInvalidator {
+	return &invalidator{store: store}
+}
+
+func (i *invalidator) InvalidateArticle(ctx context.Context, id string, categorySlugs []string) error {
+	if id == "" {
+		i.invalidateAllArticles()
+		return nil
+	}
+
+	i.store.Delete(keyArticle + id)
+	i.store.DeletePrefix(keyArticles)
+	i.store.DeletePrefix(keyPopularArticles)
+	i.store.DeletePrefix(keyLatestArticles)
+	for _, slug := range categorySlugs {
+		i.store.DeletePrefix(keyArticlesByCategory + slug + ":")
+	}
+	return nil
+}
+
+func (i *invalidator) InvalidateCategory(ctx context.Context, slug string) error {
+	i.store.DeletePrefix(keyCategories)
+	i.store.Delete(keyCategory + slug)
+	i.invalidateAllArticles()
+	return nil
+}
+
+func (i *invalidator) invalidateAllArticles() {
+	i.store.DeletePrefix(keyArticle)
+	i.store.DeletePrefix(keyArticles)
+	i.store.DeletePrefix(keyArticlesByCategory)
+	i.store.DeletePrefix(keyPopularArticles)
+	i.store.DeletePrefix(keyLatestArticles)
+}
@@ -0,0 +1,133 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// warmArticleCache fills the cache through the decorators.
+func warmArticleCache(t *testing.T, articleRepo repository.ArticleRepository, categoryRepo repository.CategoryRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+	_, err := articleRepo.GetArticles(ctx, 10, 0, nil)
+	require.NoError(t, err)
+	_, err = articleRepo.GetArticleByID(ctx, "a1")
+	require.NoError(t, err)
+	_, err = articleRepo.GetArticleByID(ctx, "a2")
+	require.NoError(t, err)
+	_, err = articleRepo.GetArticlesByCategory(ctx, "go", 10, 0, nil)
+	require.NoError(t, err)
+	_, err = articleRepo.GetArticlesByCategory(ctx, "golang", 10, 0, nil)
+	require.NoError(t, err)
+	_, err = articleRepo.GetPopularArticles(ctx, 5, nil)
+	require.NoError(t, err)
+	_, err = articleRepo.GetLatestArticles(ctx, 5, nil)
+	require.NoError(t, err)
+	_, err = categoryRepo.GetCategories(ctx)
+	require.NoError(t, err)
+	_, err = categoryRepo.GetCategoryBySlug(ctx, "go")
+	require.NoError(t, err)
+}
+
+// newWarmStore returns a store holding nine entries: two articles, two
+// category lists, the article, popular and latest lists, and two category entries.
+func newWarmStore(t *testing.T) *cache.Store {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mockArticles := mocks.NewMockArticleRepository(ctrl)
+	mockCategories := mocks.NewMockCategoryRepository(ctrl)
+
+	mockArticles.EXPECT().GetArticles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ArticlePage{}, nil).AnyTimes()
+	mockArticles.EXPECT().GetArticleByID(gomock.Any(), gomock.Any()).Return(&entity.Article{}, nil).AnyTimes()
+	mockArticles.EXPECT().GetArticlesByCategory(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ArticlePage{}, nil).AnyTimes()
+	mockArticles.EXPECT().GetPopularArticles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Article{}, nil).AnyTimes()
+	mockArticles.EXPECT().GetLatestArticles(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*entity.Article{}, nil).AnyTimes()
+	mockCategories.EXPECT().GetCategories(gomock.Any()).Return([]*entity.Category{}, nil).AnyTimes()
+	mockCategories.EXPECT().GetCategoryBySlug(gomock.Any(), gomock.Any()).Return(&entity.Category{}, nil).AnyTimes()
+
+	store := cache.NewStore(100)
+	articleRepo := cache.NewArticleRepository(mockArticles, store, testOptions)
+	categoryRepo := cache.NewCategoryRepository(mockCategories, store, testOptions)
+	warmArticleCache(t, articleRepo, categoryRepo)
+	require.Equal(t, 9, store.Len())
+
+	return store
+}
+
+func TestInvalidator_InvalidateArticle(t *testing.T) {
+	t.Parallel()
+
+	store := newWarmStore(t)
+	invalidator := cache.NewInvalidator(store)
+
+	err := invalidator.InvalidateArticle(context.Background(), "a1", []string{"go"})
+	require.NoError(t, err)
+
+	// a2, the golang category list and both category entries remain.
+	assert.Equal(t, 4, store.Len())
+}
+
+func TestInvalidator_InvalidateAllArticles(t *testing.T) {
+	t.Parallel()
+
+	store := newWarmStore(t)
+	invalidator := cache.NewInvalidator(store)
+
+	err := invalidator.InvalidateArticle(context.Background(), "", nil)
+	require.NoError(t, err)
+
+	// Only the category entries remain.
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestInvalidator_InvalidateCategory(t *testing.T) {
+	t.Parallel()
+
+	store := newWarmStore(t)
+	invalidator := cache.NewInvalidator(store)
+
+	err := invalidator.InvalidateCategory(context.Background(), "go")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestInvalidator_InFlightFetchIsNotStored(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+
+	store := cache.NewStore(10)
+	invalidator := cache.NewInvalidator(store)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "a1").
+			DoAndReturn(func(ctx context.Context, id string) (*entity.Article, error) {
+				// The article is edited while the old version is being fetched.
+				require.NoError(t, invalidator.InvalidateArticle(ctx, id, nil))
+				return &entity.Article{ID: id, Title: "old"}, nil
+			}),
+		mockRepo.EXPECT().GetArticleByID(gomock.Any(), "a1").Return(&entity.Article{ID: "a1", Title: "new"}, nil),
+	)
+
+	repo := cache.NewArticleRepository(mockRepo, store, testOptions)
+
+	got, err := repo.GetArticleByID(context.Background(), "a1")
+	require.NoError(t, err)
+	assert.Equal(t, "old", got.Title)
+
+	got, err = repo.GetArticleByID(context.Background(), "a1")
+	require.NoError(t, err)
+	assert.Equal(t, "new", got.Title)
+}
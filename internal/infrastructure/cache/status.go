@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	ResultHit   = "HIT"
+	ResultMiss  = "MISS"
+	ResultStale = "STALE"
+)
+
+// Status records how the cache answered the repository calls made while
+// serving a single request.
+type Status struct {
+	mu       sync.Mutex
+	hits     int
+	misses   int
+	stale    int
+	staleAge time.Duration
+}
+
+type statusKey struct{}
+
+// WithStatus returns a context that collects cache results into the returned Status.
+func WithStatus(ctx context.Context) (context.Context, *Status) {
+	s := &Status{}
+	return context.WithValue(ctx, statusKey{}, s), s
+}
+
+func statusFromContext(ctx context.Context) *Status {
+	s, _ := ctx.Value(statusKey{}).(*Status)
+	return s
+}
+
+// Result summarises the request: STALE if any stale data was served,
+// MISS if anything had to be fetched, HIT if everything came from the cache,
+// and an empty string if the cache was not consulted.
+func (s *Status) Result() string {
+	if s == nil {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.stale > 0:
+		return ResultStale
+	case s.misses > 0:
+		return ResultMiss
+	case s.hits > 0:
+		return ResultHit
+	default:
+		return ""
+	}
+}
+
+// StaleAge returns the age of the oldest stale entry that was served.
+func (s *Status) StaleAge() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.staleAge
+}
+
+func (s *Status) hit() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hits++
+}
+
+func (s *Status) miss() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.misses++
+}
+
+func (s *Status) servedStale(age time.Duration) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stale++
+	s.staleAge = max(s.staleAge, age)
+}
@@ -0,0 +1,295 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of the store counters.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	StaleServed uint64
+	Evictions   uint64
+	// Coalesced counts calls that joined an identical upstream call already in flight.
+	Coalesced uint64
+}
+
+// State describes how usable a cached entry is.
+type State int
+
+const (
+	// StateMiss means there is no usable entry.
+	StateMiss State = iota
+	// StateFresh means the entry is within its TTL.
+	StateFresh
+	// StateStale means the entry has expired but may be served while it is revalidated.
+	StateStale
+	// StateExpired means the entry may only be served when the upstream fails.
+	StateExpired
+)
+
+// Lifetime controls how long an entry is fresh and how long it is kept afterwards.
+type Lifetime struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+}
+
+// Entry is a cached repository result. Err is set for negative entries.
+type Entry struct {
+	Value any
+	Err   error
+	// Age is how long ago the entry was stored. It is filled in by Lookup.
+	Age time.Duration
+}
+
+type entry struct {
+	Entry
+	key             string
+	storedAt        time.Time
+	expiresAt       time.Time
+	revalidateUntil time.Time
+	retainUntil     time.Time
+}
+
+// Store is a size-bounded LRU with per-entry expiry.
+// It is shared by the repository decorators so that the bound applies to the
+// whole cache rather than to each repository. Expired entries are retained
+// for their stale windows so they can be served while revalidating or when
+// the upstream is failing.
+type Store struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	stats      Stats
+	now        func() time.Time
+	// generation changes on every deletion, so that results fetched before an
+	// invalidation are not stored after it.
+	generation uint64
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	revalidating map[string]struct{}
+	flights      *flightGroup
+}
+
+func NewStore(maxEntries int) *Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Store{
+		maxEntries:   maxEntries,
+		ll:           list.New(),
+		items:        make(map[string]*list.Element),
+		now:          time.Now,
+		ctx:          ctx,
+		cancel:       cancel,
+		revalidating: make(map[string]struct{}),
+		flights:      newFlightGroup(),
+	}
+}
+
+// Get returns the cached entry for key if it is fresh.
+func (s *Store) Get(key string) (Entry, bool) {
+	e, state := s.Lookup(key)
+	if state != StateFresh {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Lookup returns the entry for key together with its state.
+// Entries past their retention window are dropped and reported as a miss.
+func (s *Store) Lookup(key string) (Entry, State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.items[key]
+	if !ok {
+		s.stats.Misses++
+		return Entry{}, StateMiss
+	}
+
+	e := elem.Value.(*entry)
+	now := s.now()
+	if !now.Before(e.retainUntil) {
+		s.removeElement(elem)
+		s.stats.Misses++
+		return Entry{}, StateMiss
+	}
+
+	s.ll.MoveToFront(elem)
+	result := e.Entry
+	result.Age = now.Sub(e.storedAt)
+
+	switch {
+	case now.Before(e.expiresAt):
+		s.stats.Hits++
+		return result, StateFresh
+	case now.Before(e.revalidateUntil):
+		s.stats.StaleServed++
+		return result, StateStale
+	default:
+		s.stats.Misses++
+		return result, StateExpired
+	}
+}
+
+// Set stores e under key for the given lifetime.
+func (s *Store) Set(key string, e Entry, lt Lifetime) {
+	if lt.TTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set(key, e, lt)
+}
+
+// setIfGeneration stores e unless entries were deleted since gen was read.
+func (s *Store) setIfGeneration(key string, e Entry, lt Lifetime, gen uint64) {
+	if lt.TTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.generation != gen {
+		return
+	}
+	s.set(key, e, lt)
+}
+
+func (s *Store) set(key string, e Entry, lt Lifetime) {
+	now := s.now()
+	expiresAt := now.Add(lt.TTL)
+	retain := max(lt.StaleWhileRevalidate, lt.StaleIfError)
+	stored := &entry{
+		Entry:           Entry{Value: e.Value, Err: e.Err},
+		key:             key,
+		storedAt:        now,
+		expiresAt:       expiresAt,
+		revalidateUntil: expiresAt.Add(lt.StaleWhileRevalidate),
+		retainUntil:     expiresAt.Add(retain),
+	}
+
+	if elem, ok := s.items[key]; ok {
+		elem.Value = stored
+		s.ll.MoveToFront(elem)
+		return
+	}
+
+	s.items[key] = s.ll.PushFront(stored)
+
+	for s.maxEntries > 0 && s.ll.Len() > s.maxEntries {
+		s.removeElement(s.ll.Back())
+		s.stats.Evictions++
+	}
+}
+
+// Delete removes key from the store.
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	if elem, ok := s.items[key]; ok {
+		s.removeElement(elem)
+	}
+}
+
+// DeletePrefix removes every key starting with prefix and returns how many were removed.
+func (s *Store) DeletePrefix(prefix string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	removed := 0
+	for key, elem := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			s.removeElement(elem)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Len returns the number of entries currently held, including stale ones.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ll.Len()
+}
+
+func (s *Store) currentGeneration() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.generation
+}
+
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.Coalesced = s.flights.merged.Load()
+	return stats
+}
+
+func (s *Store) recordStaleServed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.StaleServed++
+}
+
+// coalesce runs fn once for all concurrent callers of key.
+func (s *Store) coalesce(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	return s.flights.do(ctx, key, fn)
+}
+
+// Close cancels in-flight revalidations and waits for them to return.
+func (s *Store) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// revalidate runs fn in the background unless a revalidation for key is
+// already running. fn receives a context bounded by timeout that is cancelled
+// when the store is closed.
+func (s *Store) revalidate(key string, timeout time.Duration, fn func(ctx context.Context)) {
+	s.mu.Lock()
+	if _, ok := s.revalidating[key]; ok || s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.revalidating[key] = struct{}{}
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.revalidating, key)
+			s.mu.Unlock()
+			s.wg.Done()
+		}()
+
+		ctx, cancel := context.WithTimeout(s.ctx, timeout)
+		defer cancel()
+		fn(ctx)
+	}()
+}
+
+func (s *Store) removeElement(elem *list.Element) {
+	s.ll.Remove(elem)
+	delete(s.items, elem.Value.(*entry).key)
+}
@@ -0,0 +1,149 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_SetAndGet(t *testing.T) {
+	t.Parallel()
+
+	store := cache.NewStore(10)
+	store.Set("key", cache.Entry{Value: "value"}, cache.Lifetime{TTL: time.Minute})
+
+	e, ok := store.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, "value", e.Value)
+	assert.NoError(t, e.Err)
+
+	_, ok = store.Get("missing")
+	assert.False(t, ok)
+
+	stats := store.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+}
+
+func TestStore_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := cache.NewStore(10)
+	store.SetNow(func() time.Time { return now })
+
+	store.Set("key", cache.Entry{Value: "value"}, cache.Lifetime{TTL: time.Minute})
+
+	now = now.Add(59 * time.Second)
+	_, ok := store.Get("key")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = store.Get("key")
+	assert.False(t, ok)
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestStore_ZeroTTLIsNotStored(t *testing.T) {
+	t.Parallel()
+
+	store := cache.NewStore(10)
+	store.Set("key", cache.Entry{Value: "value"}, cache.Lifetime{TTL: 0})
+
+	_, ok := store.Get("key")
+	assert.False(t, ok)
+}
+
+func TestStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	store := cache.NewStore(2)
+	store.Set("a", cache.Entry{Value: 1}, cache.Lifetime{TTL: time.Minute})
+	store.Set("b", cache.Entry{Value: 2}, cache.Lifetime{TTL: time.Minute})
+
+	// Touch "a" so that "b" becomes the least recently used entry
+	_, ok := store.Get("a")
+	assert.True(t, ok)
+
+	store.Set("c", cache.Entry{Value: 3}, cache.Lifetime{TTL: time.Minute})
+
+	_, ok = store.Get("b")
+	assert.False(t, ok)
+	_, ok = store.Get("a")
+	assert.True(t, ok)
+	_, ok = store.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, store.Len())
+	assert.Equal(t, uint64(1), store.Stats().Evictions)
+}
+
+func TestStore_NegativeEntry(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := errors.New("not found")
+	store := cache.NewStore(10)
+	store.Set("key", cache.Entry{Err: errNotFound}, cache.Lifetime{TTL: time.Minute})
+
+	e, ok := store.Get("key")
+	assert.True(t, ok)
+	assert.ErrorIs(t, e.Err, errNotFound)
+}
+
+func TestStore_DeletePrefix(t *testing.T) {
+	t.Parallel()
+
+	store := cache.NewStore(10)
+	store.Set("articles:10:0", cache.Entry{Value: 1}, cache.Lifetime{TTL: time.Minute})
+	store.Set("articles:10:10", cache.Entry{Value: 2}, cache.Lifetime{TTL: time.Minute})
+	store.Set("article:abc", cache.Entry{Value: 3}, cache.Lifetime{TTL: time.Minute})
+
+	removed := store.DeletePrefix("articles:")
+	assert.Equal(t, 2, removed)
+
+	_, ok := store.Get("article:abc")
+	assert.True(t, ok)
+
+	store.Delete("article:abc")
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestStore_StaleStates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := cache.NewStore(10)
+	store.SetNow(func() time.Time { return now })
+
+	store.Set("key", cache.Entry{Value: "value"}, cache.Lifetime{
+		TTL:                  time.Minute,
+		StaleWhileRevalidate: time.Minute,
+		StaleIfError:         time.Hour,
+	})
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    cache.State
+		wantAge time.Duration
+	}{
+		{name: "fresh", advance: 30 * time.Second, want: cache.StateFresh, wantAge: 30 * time.Second},
+		{name: "stale while revalidate", advance: time.Minute, want: cache.StateStale, wantAge: 90 * time.Second},
+		{name: "stale if error", advance: 30 * time.Minute, want: cache.StateExpired, wantAge: 31*time.Minute + 30*time.Second},
+		{name: "past retention", advance: time.Hour, want: cache.StateMiss},
+	}
+
+	for _, tt := range tests {
+		now = now.Add(tt.advance)
+
+		e, state := store.Lookup("key")
+		assert.Equal(t, tt.want, state, tt.name)
+		if tt.want != cache.StateMiss {
+			assert.Equal(t, "value", e.Value, tt.name)
+			assert.Equal(t, tt.wantAge, e.Age, tt.name)
+		}
+	}
+	assert.Equal(t, 0, store.Len())
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+const keyZennArticles = "zenn_articles:"
+
+type zennRepository struct {
+	next  repository.ArticleReader
+	store *Store
+	opts  Options
+}
+
+// NewZennRepository wraps the Zenn article source with a read-through cache backed by store.
+func NewZennRepository(
+	next repository.ArticleReader,
+	store *Store,
+	opts Options,
+) repository.ArticleReader {
+	return &zennRepository{
+		next:  next,
+		store: store,
+		opts:  opts,
+	}
+}
+
+func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	key := fmt.Sprintf("%s%d:%d:%s", keyZennArticles, limit, offset, fieldsKey(fields))
+	return load(ctx, r.store, key, r.opts.TTL.ZennArticles, r.opts,
+		func(ctx context.Context) (repository.ArticlePage, error) {
+			return r.next.GetArticles(ctx, limit, offset, fields)
+		})
+}
@@ -0,0 +1,49 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestZennRepository_ServesStaleWhenZennIsDown(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+
+	page := repository.ArticlePage{Articles: []*entity.Article{{ID: "zenn-article"}}}
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(page, nil),
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, errors.New("zenn API returned status 502")),
+	)
+
+	opts := staleOptions
+	opts.TTL = cache.TTL{ZennArticles: 10 * time.Minute}
+
+	store, advance := newStaleTestStore()
+	repo := cache.NewZennRepository(mockRepo, store, opts)
+
+	for i := 0; i < 2; i++ {
+		got, err := repo.GetArticles(context.Background(), 10, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, page, got)
+	}
+
+	advance(20 * time.Minute)
+
+	ctx, status := cache.WithStatus(context.Background())
+	got, err := repo.GetArticles(ctx, 10, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, page, got)
+	assert.Equal(t, cache.ResultStale, status.Result())
+}
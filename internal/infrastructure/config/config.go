@@ -1,16 +1,229 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// DefaultAPIKeyName names the key configured by NERINE_API_KEY.
+const DefaultAPIKeyName = "default"
+
 type Config struct {
 	Port              string
 	MicroCMSAPIKey    string
 	MicroCMSServiceID string
-	NerineAPIKey      string
+	MicroCMSTimeout   time.Duration
+	MicroCMSRetry     RetryConfig
 	ZennUsername      string
+	ZennRetry         RetryConfig
+	// NerineAPIKey is a single plain-text key granted the read scope under
+	// DefaultAPIKeyName. It is kept for existing deployments; new keys, and
+	// every key with the preview or admin scope, belong in APIKeys.
+	NerineAPIKey string
+	// APIKeys is the key ring read from NERINE_API_KEYS.
+	APIKeys []APIKeyConfig
+	// MicroCMSWebhookSecret verifies microCMS webhooks. The webhook endpoint
+	// is disabled when it is empty.
+	MicroCMSWebhookSecret string
+	// Debug sends the underlying error as detail in error responses.
+	// It must stay disabled in production.
+	Debug bool
+	// ShutdownTimeout bounds how long in-flight requests are drained after
+	// SIGINT or SIGTERM before the remaining connections are closed.
+	ShutdownTimeout time.Duration
+	// MetricsEnabled serves Prometheus metrics on /metrics without an API key.
+	MetricsEnabled bool
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For gives the
+	// client IP. With none, the address of the connection is the client IP.
+	TrustedProxies []*net.IPNet
+
+	Cache          CacheConfig
+	HTTPCache      HTTPCacheConfig
+	CircuitBreaker CircuitBreakerConfig
+	Readiness      ReadinessConfig
+	Tracing        TracingConfig
+	Log            LogConfig
+	RateLimit      RateLimitConfig
+	CORS           CORSConfig
+	Preview        PreviewConfig
+	Popularity     PopularityConfig
+}
+
+// PopularityConfig controls the ranking of popular articles by page views.
+// Views older than Window are ignored and lose half their weight every
+// HalfLife; a visitor is counted once per article within DedupWindow. Store
+// is "memory", saved to SnapshotPath every SnapshotInterval when a path is
+// set (there is no default, so a run never writes into its working
+// directory), or "redis" to share the counts between replicas.
+type PopularityConfig struct {
+	Window           time.Duration
+	HalfLife         time.Duration
+	DedupWindow      time.Duration
+	Store            string
+	RedisURL         string
+	SnapshotPath     string
+	SnapshotInterval time.Duration
+}
+
+// PreviewConfig controls the encrypted preview links to article drafts. The
+// preview link endpoints are disabled when TokenSecret is empty; changing it
+// revokes every link issued before. TokenTTL is the lifetime of a link unless
+// the request asks for another, up to MaxTokenTTL.
+type PreviewConfig struct {
+	TokenSecret string
+	TokenTTL    time.Duration
+	MaxTokenTTL time.Duration
+}
+
+// APIKeyConfig is a named API key. Only the SHA-256 digest of the key is
+// configured. Scopes are read, preview or admin, and a zero ExpiresAt never
+// expires. RateLimit, when set, replaces the rate limit of the key's scopes.
+type APIKeyConfig struct {
+	Name      string
+	SHA256    [sha256.Size]byte
+	Scopes    []string
+	ExpiresAt time.Time
+	RateLimit *RateLimit
+}
+
+// RateLimit allows Requests per Period. It is written as "600/1m"; the zero
+// value, written "unlimited", does not limit.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitConfig controls the rate limits of API keys and, on routes without
+// one, of client IPs. Scopes overrides Key for requests authorized for a
+// scope. Store is "memory", or "redis" to share the limits between replicas.
+type RateLimitConfig struct {
+	Enabled  bool
+	Key      RateLimit
+	IP       RateLimit
+	Scopes   map[string]RateLimit
+	Store    string
+	RedisURL string
+}
+
+// CORSConfig controls which browser origins may call the API. Profile sets
+// the starting point: "production" allows only AllowOrigins, "development"
+// also allows localhost on any port, and "open" allows every origin.
+type CORSConfig struct {
+	Profile          string
+	AllowOrigins     []string
+	AllowHeaders     []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CacheConfig controls the in-memory cache placed in front of the repositories.
+// Expired content is served for StaleWhileRevalidate while it is refreshed in
+// the background, and kept for StaleIfError to answer requests while the
+// upstream is failing.
+type CacheConfig struct {
+	Enabled              bool
+	MaxEntries           int
+	NegativeTTL          time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+	RevalidateTimeout    time.Duration
+	TTL                  CacheTTLConfig
+}
+
+// CacheTTLConfig holds the TTL for each cached repository method.
+// A zero TTL disables caching for that method.
+type CacheTTLConfig struct {
+	Articles           time.Duration
+	Article            time.Duration
+	ArticlesByCategory time.Duration
+	PopularArticles    time.Duration
+	LatestArticles     time.Duration
+	Categories         time.Duration
+	Category           time.Duration
+	ZennArticles       time.Duration
+}
+
+// RetryConfig controls how transient upstream failures are retried.
+// MaxAttempts includes the first request, so 1 disables retries.
+type RetryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// CircuitBreakerConfig controls the circuit breakers in front of microCMS and
+// Zenn. Each upstream has its own breaker with these settings.
+type CircuitBreakerConfig struct {
+	FailureRatio     float64
+	MinRequests      int
+	Interval         time.Duration
+	Cooldown         time.Duration
+	HalfOpenRequests int
+}
+
+// ReadinessConfig controls the upstream probes of the readiness endpoint.
+// microCMS is always required; Zenn only when ZennRequired is set.
+type ReadinessConfig struct {
+	CacheTTL     time.Duration
+	ProbeTimeout time.Duration
+	ZennRequired bool
+}
+
+// TracingConfig controls OpenTelemetry tracing. Exporter is "none", "stdout"
+// or "otlp"; the OTLP endpoint is read from OTEL_EXPORTER_OTLP_ENDPOINT.
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+}
+
+// LogConfig controls the logger. Level is debug, info, warn or error and
+// Format json or console. Logs go to File when it is set, otherwise to stderr.
+type LogConfig struct {
+	Level    string
+	Format   string
+	Sampling LogSamplingConfig
+	File     LogFileConfig
+	// LevelEndpointEnabled serves the runtime log level under /admin. It is
+	// off by default so that a leaked key cannot change production logging.
+	LevelEndpointEnabled bool
+}
+
+// LogSamplingConfig limits repeated entries with the same level and message
+// per second. A zero Initial disables sampling.
+type LogSamplingConfig struct {
+	Initial    int
+	Thereafter int
+}
+
+// LogFileConfig controls the rotation of the log file.
+type LogFileConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// HTTPCacheConfig holds the Cache-Control header sent with successful
+// responses of each read endpoint.
+type HTTPCacheConfig struct {
+	Articles           string
+	Article            string
+	ArticlesByCategory string
+	PopularArticles    string
+	LatestArticles     string
+	Categories         string
+	ZennArticles       string
 }
 
 func Load() (*Config, error) {
@@ -19,8 +232,102 @@ func Load() (*Config, error) {
 		MicroCMSAPIKey:    os.Getenv("MICROCMS_API_KEY"),
 		MicroCMSServiceID: os.Getenv("MICROCMS_SERVICE_ID"),
 		NerineAPIKey:      os.Getenv("NERINE_API_KEY"),
+
+		MicroCMSWebhookSecret: os.Getenv("MICROCMS_WEBHOOK_SECRET"),
+	}
+
+	apiKeys, err := loadAPIKeys()
+	if err != nil {
+		return nil, err
+	}
+	cfg.APIKeys = apiKeys
+
+	p := &envParser{}
+	cfg.MicroCMSTimeout = p.duration("MICROCMS_TIMEOUT", 10*time.Second)
+	cfg.Debug = p.bool("DEBUG", false)
+	cfg.ShutdownTimeout = p.duration("SHUTDOWN_TIMEOUT", 15*time.Second)
+	cfg.MetricsEnabled = p.bool("METRICS_ENABLED", true)
+	if p.err != nil {
+		return nil, p.err
+	}
+	// http.Client treats a zero timeout as none at all.
+	if cfg.MicroCMSTimeout <= 0 {
+		return nil, errors.New("MICROCMS_TIMEOUT must be greater than 0")
 	}
 
+	trustedProxies, err := loadTrustedProxies()
+	if err != nil {
+		return nil, err
+	}
+	cfg.TrustedProxies = trustedProxies
+
+	microCMSRetry, err := loadRetryConfig("MICROCMS")
+	if err != nil {
+		return nil, err
+	}
+	cfg.MicroCMSRetry = microCMSRetry
+
+	zennRetry, err := loadRetryConfig("ZENN")
+	if err != nil {
+		return nil, err
+	}
+	cfg.ZennRetry = zennRetry
+
+	cacheCfg, err := loadCacheConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Cache = cacheCfg
+	cfg.HTTPCache = loadHTTPCacheConfig()
+
+	breakerCfg, err := loadCircuitBreakerConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.CircuitBreaker = breakerCfg
+
+	readinessCfg, err := loadReadinessConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Readiness = readinessCfg
+
+	tracingCfg, err := loadTracingConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Tracing = tracingCfg
+
+	logCfg, err := loadLogConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Log = logCfg
+
+	rateLimitCfg, err := loadRateLimitConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.RateLimit = rateLimitCfg
+
+	corsCfg, err := loadCORSConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.CORS = corsCfg
+
+	previewCfg, err := loadPreviewConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Preview = previewCfg
+
+	popularityCfg, err := loadPopularityConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Popularity = popularityCfg
+
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
@@ -28,6 +335,433 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// loadAPIKeys reads NERINE_API_KEYS, a JSON array such as
+//
+//	[{"name":"web","sha256":"<hex digest>","scopes":["read"],"expiresAt":"2026-01-01T00:00:00Z"}]
+func loadAPIKeys() ([]APIKeyConfig, error) {
+	value := os.Getenv("NERINE_API_KEYS")
+	if value == "" {
+		return nil, nil
+	}
+
+	var entries []struct {
+		Name      string    `json:"name"`
+		SHA256    string    `json:"sha256"`
+		Scopes    []string  `json:"scopes"`
+		ExpiresAt time.Time `json:"expiresAt"`
+		RateLimit string    `json:"rateLimit"`
+	}
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return nil, fmt.Errorf("NERINE_API_KEYS must be a JSON array of keys: %w", err)
+	}
+
+	keys := make([]APIKeyConfig, 0, len(entries))
+	names := make(map[string]bool, len(entries))
+	digests := make(map[[sha256.Size]byte]bool, len(entries))
+	for _, entry := range entries {
+		if entry.Name == "" {
+			return nil, errors.New("NERINE_API_KEYS must give every key a name")
+		}
+		if names[entry.Name] {
+			return nil, fmt.Errorf("NERINE_API_KEYS has more than one key named %q", entry.Name)
+		}
+		names[entry.Name] = true
+
+		key := APIKeyConfig{
+			Name:      entry.Name,
+			Scopes:    entry.Scopes,
+			ExpiresAt: entry.ExpiresAt,
+		}
+		digest, err := hex.DecodeString(entry.SHA256)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("NERINE_API_KEYS key %q must have a hex-encoded SHA-256 digest", entry.Name)
+		}
+		copy(key.SHA256[:], digest)
+		if digests[key.SHA256] {
+			return nil, fmt.Errorf("NERINE_API_KEYS key %q has the same digest as another key", entry.Name)
+		}
+		digests[key.SHA256] = true
+
+		if len(entry.Scopes) == 0 {
+			return nil, fmt.Errorf("NERINE_API_KEYS key %q must have at least one scope", entry.Name)
+		}
+		for _, scope := range entry.Scopes {
+			switch scope {
+			case "read", "preview", "admin":
+			default:
+				return nil, fmt.Errorf("NERINE_API_KEYS key %q has unknown scope %q, must be read, preview or admin", entry.Name, scope)
+			}
+		}
+
+		if entry.RateLimit != "" {
+			limit, err := parseRateLimit(entry.RateLimit)
+			if err != nil {
+				return nil, fmt.Errorf("NERINE_API_KEYS key %q must have a rate limit such as 600/1m: %w", entry.Name, err)
+			}
+			key.RateLimit = &limit
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func loadCacheConfig() (CacheConfig, error) {
+	p := &envParser{}
+	cfg := CacheConfig{
+		Enabled:              p.bool("CACHE_ENABLED", true),
+		MaxEntries:           p.int("CACHE_MAX_ENTRIES", 1000),
+		NegativeTTL:          p.duration("CACHE_NEGATIVE_TTL", 30*time.Second),
+		StaleWhileRevalidate: p.duration("CACHE_STALE_WHILE_REVALIDATE", time.Minute),
+		StaleIfError:         p.duration("CACHE_STALE_IF_ERROR", 24*time.Hour),
+		RevalidateTimeout:    p.duration("CACHE_REVALIDATE_TIMEOUT", 10*time.Second),
+		TTL: CacheTTLConfig{
+			Articles:           p.duration("CACHE_TTL_ARTICLES", time.Minute),
+			Article:            p.duration("CACHE_TTL_ARTICLE", 5*time.Minute),
+			ArticlesByCategory: p.duration("CACHE_TTL_ARTICLES_BY_CATEGORY", time.Minute),
+			PopularArticles:    p.duration("CACHE_TTL_POPULAR_ARTICLES", 5*time.Minute),
+			LatestArticles:     p.duration("CACHE_TTL_LATEST_ARTICLES", time.Minute),
+			Categories:         p.duration("CACHE_TTL_CATEGORIES", 30*time.Minute),
+			Category:           p.duration("CACHE_TTL_CATEGORY", 30*time.Minute),
+			ZennArticles:       p.duration("CACHE_TTL_ZENN_ARTICLES", 10*time.Minute),
+		},
+	}
+	if p.err != nil {
+		return CacheConfig{}, p.err
+	}
+	if cfg.MaxEntries <= 0 {
+		return CacheConfig{}, errors.New("CACHE_MAX_ENTRIES must be greater than 0")
+	}
+	return cfg, nil
+}
+
+// loadRetryConfig reads the retry settings of one upstream, e.g.
+// ZENN_RETRY_MAX_ATTEMPTS for the prefix ZENN.
+func loadRetryConfig(prefix string) (RetryConfig, error) {
+	p := &envParser{}
+	cfg := RetryConfig{
+		MaxAttempts: p.int(prefix+"_RETRY_MAX_ATTEMPTS", 3),
+		BaseDelay:   p.duration(prefix+"_RETRY_BASE_DELAY", 200*time.Millisecond),
+		MaxDelay:    p.duration(prefix+"_RETRY_MAX_DELAY", 2*time.Second),
+	}
+	if p.err != nil {
+		return RetryConfig{}, p.err
+	}
+	if cfg.MaxAttempts <= 0 {
+		return RetryConfig{}, fmt.Errorf("%s_RETRY_MAX_ATTEMPTS must be greater than 0", prefix)
+	}
+	return cfg, nil
+}
+
+func loadCircuitBreakerConfig() (CircuitBreakerConfig, error) {
+	p := &envParser{}
+	cfg := CircuitBreakerConfig{
+		FailureRatio:     p.float("CIRCUIT_BREAKER_FAILURE_RATIO", 0.5),
+		MinRequests:      p.int("CIRCUIT_BREAKER_MIN_REQUESTS", 10),
+		Interval:         p.duration("CIRCUIT_BREAKER_INTERVAL", time.Minute),
+		Cooldown:         p.duration("CIRCUIT_BREAKER_COOLDOWN", 30*time.Second),
+		HalfOpenRequests: p.int("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", 1),
+	}
+	if p.err != nil {
+		return CircuitBreakerConfig{}, p.err
+	}
+	if cfg.FailureRatio <= 0 || cfg.FailureRatio > 1 {
+		return CircuitBreakerConfig{}, errors.New("CIRCUIT_BREAKER_FAILURE_RATIO must be greater than 0 and at most 1")
+	}
+	if cfg.MinRequests <= 0 {
+		return CircuitBreakerConfig{}, errors.New("CIRCUIT_BREAKER_MIN_REQUESTS must be greater than 0")
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		return CircuitBreakerConfig{}, errors.New("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS must be greater than 0")
+	}
+	return cfg, nil
+}
+
+func loadReadinessConfig() (ReadinessConfig, error) {
+	p := &envParser{}
+	cfg := ReadinessConfig{
+		CacheTTL:     p.duration("READINESS_CACHE_TTL", 10*time.Second),
+		ProbeTimeout: p.duration("READINESS_PROBE_TIMEOUT", 3*time.Second),
+		ZennRequired: p.bool("READINESS_ZENN_REQUIRED", false),
+	}
+	if p.err != nil {
+		return ReadinessConfig{}, p.err
+	}
+	if cfg.ProbeTimeout <= 0 {
+		return ReadinessConfig{}, errors.New("READINESS_PROBE_TIMEOUT must be greater than 0")
+	}
+	return cfg, nil
+}
+
+func loadTracingConfig() (TracingConfig, error) {
+	p := &envParser{}
+	cfg := TracingConfig{
+		Exporter:    getEnvOrDefault("TRACING_EXPORTER", "none"),
+		ServiceName: getEnvOrDefault("OTEL_SERVICE_NAME", "nerine"),
+		SampleRatio: p.float("TRACING_SAMPLE_RATIO", 1),
+	}
+	if p.err != nil {
+		return TracingConfig{}, p.err
+	}
+	switch cfg.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		return TracingConfig{}, fmt.Errorf("TRACING_EXPORTER must be one of none, stdout or otlp, got %q", cfg.Exporter)
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return TracingConfig{}, errors.New("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+	return cfg, nil
+}
+
+func loadLogConfig() (LogConfig, error) {
+	p := &envParser{}
+	cfg := LogConfig{
+		Level:  getEnvOrDefault("LOG_LEVEL", "info"),
+		Format: getEnvOrDefault("LOG_FORMAT", "json"),
+		Sampling: LogSamplingConfig{
+			Initial:    p.int("LOG_SAMPLING_INITIAL", 100),
+			Thereafter: p.int("LOG_SAMPLING_THEREAFTER", 100),
+		},
+		File: LogFileConfig{
+			Path:       os.Getenv("LOG_FILE"),
+			MaxSizeMB:  p.int("LOG_FILE_MAX_SIZE_MB", 100),
+			MaxBackups: p.int("LOG_FILE_MAX_BACKUPS", 7),
+			MaxAgeDays: p.int("LOG_FILE_MAX_AGE_DAYS", 30),
+			Compress:   p.bool("LOG_FILE_COMPRESS", false),
+		},
+		LevelEndpointEnabled: p.bool("LOG_LEVEL_ENDPOINT_ENABLED", false),
+	}
+	if p.err != nil {
+		return LogConfig{}, p.err
+	}
+	switch cfg.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return LogConfig{}, fmt.Errorf("LOG_LEVEL must be one of debug, info, warn or error, got %q", cfg.Level)
+	}
+	switch cfg.Format {
+	case "json", "console":
+	default:
+		return LogConfig{}, fmt.Errorf("LOG_FORMAT must be json or console, got %q", cfg.Format)
+	}
+	if cfg.Sampling.Initial < 0 {
+		return LogConfig{}, errors.New("LOG_SAMPLING_INITIAL must not be negative")
+	}
+	if cfg.Sampling.Thereafter <= 0 {
+		return LogConfig{}, errors.New("LOG_SAMPLING_THEREAFTER must be greater than 0")
+	}
+	if cfg.File.MaxSizeMB <= 0 {
+		return LogConfig{}, errors.New("LOG_FILE_MAX_SIZE_MB must be greater than 0")
+	}
+	if cfg.File.MaxBackups < 0 || cfg.File.MaxAgeDays < 0 {
+		return LogConfig{}, errors.New("LOG_FILE_MAX_BACKUPS and LOG_FILE_MAX_AGE_DAYS must not be negative")
+	}
+	return cfg, nil
+}
+
+func loadRateLimitConfig() (RateLimitConfig, error) {
+	p := &envParser{}
+	cfg := RateLimitConfig{
+		Enabled:  p.bool("RATE_LIMIT_ENABLED", true),
+		Key:      p.rateLimit("RATE_LIMIT_KEY", RateLimit{Requests: 600, Period: time.Minute}),
+		IP:       p.rateLimit("RATE_LIMIT_IP", RateLimit{Requests: 60, Period: time.Minute}),
+		Scopes:   make(map[string]RateLimit),
+		Store:    getEnvOrDefault("RATE_LIMIT_STORE", "memory"),
+		RedisURL: os.Getenv("RATE_LIMIT_REDIS_URL"),
+	}
+	for _, scope := range []string{"read", "preview", "admin"} {
+		key := "RATE_LIMIT_SCOPE_" + strings.ToUpper(scope)
+		if os.Getenv(key) != "" {
+			cfg.Scopes[scope] = p.rateLimit(key, RateLimit{})
+		}
+	}
+	if p.err != nil {
+		return RateLimitConfig{}, p.err
+	}
+	switch cfg.Store {
+	case "memory":
+	case "redis":
+		if cfg.RedisURL == "" {
+			return RateLimitConfig{}, errors.New("RATE_LIMIT_REDIS_URL is required when RATE_LIMIT_STORE is redis")
+		}
+		if u, err := url.Parse(cfg.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			return RateLimitConfig{}, errors.New("RATE_LIMIT_REDIS_URL must be a redis:// or rediss:// URL")
+		}
+	default:
+		return RateLimitConfig{}, fmt.Errorf("RATE_LIMIT_STORE must be memory or redis, got %q", cfg.Store)
+	}
+	return cfg, nil
+}
+
+// loadTrustedProxies reads TRUSTED_PROXIES, a comma-separated list of CIDRs.
+// A single address stands for a network of just that address.
+func loadTrustedProxies() ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, item := range getEnvList("TRUSTED_PROXIES", nil) {
+		cidr := item
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("TRUSTED_PROXIES must list CIDRs such as 10.0.0.0/8 or addresses, got %q", item)
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+// developmentOrigins are added to the allowed origins by the development
+// CORS profile.
+var developmentOrigins = []string{"http://localhost:*", "http://127.0.0.1:*"}
+
+func loadCORSConfig() (CORSConfig, error) {
+	p := &envParser{}
+	cfg := CORSConfig{
+		Profile:      getEnvOrDefault("CORS_PROFILE", "production"),
+		AllowOrigins: getEnvList("CORS_ALLOWED_ORIGINS", nil),
+		AllowHeaders: getEnvList("CORS_ALLOWED_HEADERS", []string{
+			"Accept", "Content-Type", "If-None-Match", "If-Modified-Since", "X-API-Key", "X-Request-ID", "traceparent",
+		}),
+		AllowCredentials: p.bool("CORS_ALLOW_CREDENTIALS", false),
+		MaxAge:           p.duration("CORS_MAX_AGE", 10*time.Minute),
+	}
+	if p.err != nil {
+		return CORSConfig{}, p.err
+	}
+
+	switch cfg.Profile {
+	case "production":
+	case "development":
+		cfg.AllowOrigins = append(cfg.AllowOrigins, developmentOrigins...)
+	case "open":
+		cfg.AllowOrigins = []string{"*"}
+	default:
+		return CORSConfig{}, fmt.Errorf("CORS_PROFILE must be one of production, development or open, got %q", cfg.Profile)
+	}
+
+	for _, origin := range cfg.AllowOrigins {
+		if origin == "*" {
+			if cfg.AllowCredentials {
+				return CORSConfig{}, errors.New("CORS_ALLOW_CREDENTIALS cannot be used while every origin is allowed")
+			}
+			continue
+		}
+		scheme, host, ok := strings.Cut(origin, "://")
+		if !ok || (scheme != "http" && scheme != "https") || host == "" || strings.ContainsAny(host, "/?#@") {
+			return CORSConfig{}, fmt.Errorf("CORS_ALLOWED_ORIGINS must list origins such as https://example.com or https://*.example.com, got %q", origin)
+		}
+	}
+	return cfg, nil
+}
+
+// minPreviewTokenSecretLength is the shortest accepted preview token secret,
+// the size of the AES-256 key derived from it.
+const minPreviewTokenSecretLength = 32
+
+func loadPreviewConfig() (PreviewConfig, error) {
+	p := &envParser{}
+	cfg := PreviewConfig{
+		TokenSecret: os.Getenv("PREVIEW_TOKEN_SECRET"),
+		TokenTTL:    p.duration("PREVIEW_TOKEN_TTL", 24*time.Hour),
+		MaxTokenTTL: p.duration("PREVIEW_TOKEN_MAX_TTL", 7*24*time.Hour),
+	}
+	if p.err != nil {
+		return PreviewConfig{}, p.err
+	}
+
+	if cfg.TokenSecret != "" && len(cfg.TokenSecret) < minPreviewTokenSecretLength {
+		return PreviewConfig{}, fmt.Errorf("PREVIEW_TOKEN_SECRET must be at least %d characters", minPreviewTokenSecretLength)
+	}
+	if cfg.TokenTTL <= 0 {
+		return PreviewConfig{}, errors.New("PREVIEW_TOKEN_TTL must be positive")
+	}
+	if cfg.MaxTokenTTL < cfg.TokenTTL {
+		return PreviewConfig{}, errors.New("PREVIEW_TOKEN_MAX_TTL must not be shorter than PREVIEW_TOKEN_TTL")
+	}
+	return cfg, nil
+}
+
+func loadPopularityConfig() (PopularityConfig, error) {
+	p := &envParser{}
+	cfg := PopularityConfig{
+		Window:           p.duration("POPULARITY_WINDOW", 7*24*time.Hour),
+		HalfLife:         p.duration("POPULARITY_HALF_LIFE", 24*time.Hour),
+		DedupWindow:      p.duration("POPULARITY_DEDUP_WINDOW", 30*time.Minute),
+		Store:            getEnvOrDefault("POPULARITY_STORE", "memory"),
+		RedisURL:         os.Getenv("POPULARITY_REDIS_URL"),
+		SnapshotPath:     os.Getenv("POPULARITY_SNAPSHOT_PATH"),
+		SnapshotInterval: p.duration("POPULARITY_SNAPSHOT_INTERVAL", time.Minute),
+	}
+	if p.err != nil {
+		return PopularityConfig{}, p.err
+	}
+
+	// Views are counted in hourly buckets.
+	if cfg.Window < time.Hour {
+		return PopularityConfig{}, errors.New("POPULARITY_WINDOW must be at least 1h")
+	}
+	if cfg.HalfLife <= 0 {
+		return PopularityConfig{}, errors.New("POPULARITY_HALF_LIFE must be positive")
+	}
+	if cfg.SnapshotInterval <= 0 {
+		return PopularityConfig{}, errors.New("POPULARITY_SNAPSHOT_INTERVAL must be positive")
+	}
+	switch cfg.Store {
+	case "memory":
+	case "redis":
+		if cfg.RedisURL == "" {
+			return PopularityConfig{}, errors.New("POPULARITY_REDIS_URL is required when POPULARITY_STORE is redis")
+		}
+		if u, err := url.Parse(cfg.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") {
+			return PopularityConfig{}, errors.New("POPULARITY_REDIS_URL must be a redis:// or rediss:// URL")
+		}
+	default:
+		return PopularityConfig{}, fmt.Errorf("POPULARITY_STORE must be memory or redis, got %q", cfg.Store)
+	}
+	return cfg, nil
+}
+
+// parseRateLimit parses "<requests>/<period>", such as "600/1m", or
+// "unlimited".
+func parseRateLimit(value string) (RateLimit, error) {
+	if value == "unlimited" {
+		return RateLimit{}, nil
+	}
+	requestsText, periodText, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("missing period in %q", value)
+	}
+	requests, err := strconv.Atoi(requestsText)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	period, err := time.ParseDuration(periodText)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	if requests <= 0 || period <= 0 {
+		return RateLimit{}, fmt.Errorf("requests and period of %q must be greater than 0", value)
+	}
+	return RateLimit{Requests: requests, Period: period}, nil
+}
+
+func loadHTTPCacheConfig() HTTPCacheConfig {
+	return HTTPCacheConfig{
+		Articles:           getEnvOrDefault("CACHE_CONTROL_ARTICLES", "private, max-age=60"),
+		Article:            getEnvOrDefault("CACHE_CONTROL_ARTICLE", "private, max-age=300"),
+		ArticlesByCategory: getEnvOrDefault("CACHE_CONTROL_ARTICLES_BY_CATEGORY", "private, max-age=60"),
+		PopularArticles:    getEnvOrDefault("CACHE_CONTROL_POPULAR_ARTICLES", "private, max-age=300"),
+		LatestArticles:     getEnvOrDefault("CACHE_CONTROL_LATEST_ARTICLES", "private, max-age=60"),
+		Categories:         getEnvOrDefault("CACHE_CONTROL_CATEGORIES", "private, max-age=1800"),
+		ZennArticles:       getEnvOrDefault("CACHE_CONTROL_ZENN_ARTICLES", "private, max-age=600"),
+	}
+}
+
 func (c *Config) validate() error {
 	if c.MicroCMSAPIKey == "" {
 		return errors.New("MICROCMS_API_KEY is required")
@@ -35,8 +769,19 @@ func (c *Config) validate() error {
 	if c.MicroCMSServiceID == "" {
 		return errors.New("MICROCMS_SERVICE_ID is required")
 	}
-	if c.NerineAPIKey == "" {
-		return errors.New("NERINE_API_KEY is required")
+	if c.NerineAPIKey == "" && len(c.APIKeys) == 0 {
+		return errors.New("NERINE_API_KEY or NERINE_API_KEYS is required")
+	}
+	if c.NerineAPIKey != "" {
+		digest := sha256.Sum256([]byte(c.NerineAPIKey))
+		for _, key := range c.APIKeys {
+			if key.Name == DefaultAPIKeyName {
+				return fmt.Errorf("NERINE_API_KEYS must not name a key %q while NERINE_API_KEY is set", DefaultAPIKeyName)
+			}
+			if key.SHA256 == digest {
+				return fmt.Errorf("NERINE_API_KEYS key %q is the same as NERINE_API_KEY", key.Name)
+			}
+		}
 	}
 	return nil
 }
@@ -47,3 +792,104 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma-separated variable, dropping empty items.
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// envParser reads typed environment variables and keeps the first parse error,
+// so a whole block of settings can be loaded before checking for failures.
+type envParser struct {
+	err error
+}
+
+func (p *envParser) bool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		p.fail(key, "a boolean", err)
+		return defaultValue
+	}
+	return b
+}
+
+func (p *envParser) int(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		p.fail(key, "an integer", err)
+		return defaultValue
+	}
+	return i
+}
+
+func (p *envParser) float(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.fail(key, "a number", err)
+		return defaultValue
+	}
+	return f
+}
+
+func (p *envParser) duration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		p.fail(key, "a duration", err)
+		return defaultValue
+	}
+	if d < 0 {
+		p.fail(key, "a non-negative duration", nil)
+		return defaultValue
+	}
+	return d
+}
+
+func (p *envParser) rateLimit(key string, defaultValue RateLimit) RateLimit {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	limit, err := parseRateLimit(value)
+	if err != nil {
+		p.fail(key, "a rate limit such as 600/1m or unlimited", err)
+		return defaultValue
+	}
+	return limit
+}
+
+func (p *envParser) fail(key, kind string, err error) {
+	if p.err != nil {
+		return
+	}
+	if err != nil {
+		p.err = fmt.Errorf("%s must be %s: %w", key, kind, err)
+		return
+	}
+	p.err = fmt.Errorf("%s must be %s", key, kind)
+}
@@ -1,8 +1,12 @@
 package config_test
 
 import (
+	"crypto/sha256"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kozennoki/nerine/internal/infrastructure/config"
 )
@@ -148,7 +152,7 @@ func TestLoad_MissingNerineAPIKey(t *testing.T) {
 		t.Error("Expected cfg to be nil when validation fails")
 	}
 
-	expectedErr := "NERINE_API_KEY is required"
+	expectedErr := "NERINE_API_KEY or NERINE_API_KEYS is required"
 	if err.Error() != expectedErr {
 		t.Errorf("Expected error message '%s', got: %s", expectedErr, err.Error())
 	}
@@ -256,7 +260,41 @@ func TestConfig_validate(t *testing.T) {
 				NerineAPIKey:      "",
 			},
 			expectError: true,
-			errorMsg:    "NERINE_API_KEY is required",
+			errorMsg:    "NERINE_API_KEY or NERINE_API_KEYS is required",
+		},
+		{
+			name: "Key ring without NerineAPIKey",
+			config: config.Config{
+				Port:              "8080",
+				MicroCMSAPIKey:    "test-key",
+				MicroCMSServiceID: "test-service",
+				APIKeys:           []config.APIKeyConfig{{Name: "web", Scopes: []string{"read"}}},
+			},
+			expectError: false,
+		},
+		{
+			name: "Key ring repeating NerineAPIKey",
+			config: config.Config{
+				Port:              "8080",
+				MicroCMSAPIKey:    "test-key",
+				MicroCMSServiceID: "test-service",
+				NerineAPIKey:      "test-nerine",
+				APIKeys:           []config.APIKeyConfig{{Name: "web", SHA256: sha256.Sum256([]byte("test-nerine")), Scopes: []string{"read"}}},
+			},
+			expectError: true,
+			errorMsg:    `NERINE_API_KEYS key "web" is the same as NERINE_API_KEY`,
+		},
+		{
+			name: "Key ring reusing the default name",
+			config: config.Config{
+				Port:              "8080",
+				MicroCMSAPIKey:    "test-key",
+				MicroCMSServiceID: "test-service",
+				NerineAPIKey:      "test-nerine",
+				APIKeys:           []config.APIKeyConfig{{Name: "default", Scopes: []string{"read"}}},
+			},
+			expectError: true,
+			errorMsg:    `NERINE_API_KEYS must not name a key "default" while NERINE_API_KEY is set`,
 		},
 	}
 
@@ -280,3 +318,973 @@ func TestConfig_validate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_CacheDefaults(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !cfg.Cache.Enabled {
+		t.Error("Expected cache to be enabled by default")
+	}
+	if cfg.Cache.MaxEntries != 1000 {
+		t.Errorf("Expected default MaxEntries to be 1000, got: %d", cfg.Cache.MaxEntries)
+	}
+	if cfg.Cache.TTL.Article != 5*time.Minute {
+		t.Errorf("Expected default article TTL to be 5m, got: %s", cfg.Cache.TTL.Article)
+	}
+	if cfg.Cache.NegativeTTL != 30*time.Second {
+		t.Errorf("Expected default negative TTL to be 30s, got: %s", cfg.Cache.NegativeTTL)
+	}
+}
+
+func TestLoad_CacheOverrides(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+	os.Setenv("CACHE_ENABLED", "false")
+	os.Setenv("CACHE_MAX_ENTRIES", "50")
+	os.Setenv("CACHE_TTL_CATEGORIES", "1h")
+	os.Setenv("CACHE_TTL_ARTICLES", "0s")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("CACHE_ENABLED")
+		os.Unsetenv("CACHE_MAX_ENTRIES")
+		os.Unsetenv("CACHE_TTL_CATEGORIES")
+		os.Unsetenv("CACHE_TTL_ARTICLES")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.Cache.Enabled {
+		t.Error("Expected cache to be disabled")
+	}
+	if cfg.Cache.MaxEntries != 50 {
+		t.Errorf("Expected MaxEntries to be 50, got: %d", cfg.Cache.MaxEntries)
+	}
+	if cfg.Cache.TTL.Categories != time.Hour {
+		t.Errorf("Expected categories TTL to be 1h, got: %s", cfg.Cache.TTL.Categories)
+	}
+	if cfg.Cache.TTL.Articles != 0 {
+		t.Errorf("Expected articles TTL to be 0, got: %s", cfg.Cache.TTL.Articles)
+	}
+}
+
+func TestLoad_HTTPCacheConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+	os.Setenv("CACHE_CONTROL_ARTICLE", "no-cache")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("CACHE_CONTROL_ARTICLE")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.HTTPCache.Article != "no-cache" {
+		t.Errorf("Expected article Cache-Control to be 'no-cache', got: %s", cfg.HTTPCache.Article)
+	}
+	if cfg.HTTPCache.Categories != "private, max-age=1800" {
+		t.Errorf("Expected default categories Cache-Control, got: %s", cfg.HTTPCache.Categories)
+	}
+}
+
+func TestLoad_Debug(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("DEBUG")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.Debug {
+		t.Error("Expected debug mode to be disabled by default")
+	}
+
+	os.Setenv("DEBUG", "true")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.Debug {
+		t.Error("Expected debug mode to be enabled")
+	}
+}
+
+func TestLoad_MetricsEnabled(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("METRICS_ENABLED")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.MetricsEnabled {
+		t.Error("Expected metrics to be enabled by default")
+	}
+
+	os.Setenv("METRICS_ENABLED", "false")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.MetricsEnabled {
+		t.Error("Expected metrics to be disabled")
+	}
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("TRUSTED_PROXIES")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cfg.TrustedProxies) != 0 {
+		t.Errorf("Expected no trusted proxies by default, got: %v", cfg.TrustedProxies)
+	}
+
+	os.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 192.0.2.1, 2001:db8::1")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := []string{"10.0.0.0/8", "192.0.2.1/32", "2001:db8::1/128"}
+	if len(cfg.TrustedProxies) != len(want) {
+		t.Fatalf("Expected trusted proxies %v, got: %v", want, cfg.TrustedProxies)
+	}
+	for i, proxy := range cfg.TrustedProxies {
+		if proxy.String() != want[i] {
+			t.Errorf("Expected trusted proxy %s, got: %s", want[i], proxy)
+		}
+	}
+}
+
+func TestLoad_ShutdownTimeout(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("SHUTDOWN_TIMEOUT")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ShutdownTimeout != 15*time.Second {
+		t.Errorf("Expected default shutdown timeout of 15s, got: %v", cfg.ShutdownTimeout)
+	}
+
+	os.Setenv("SHUTDOWN_TIMEOUT", "30s")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ShutdownTimeout != 30*time.Second {
+		t.Errorf("Expected shutdown timeout of 30s, got: %v", cfg.ShutdownTimeout)
+	}
+
+	os.Setenv("SHUTDOWN_TIMEOUT", "soon")
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected an error for an invalid SHUTDOWN_TIMEOUT")
+	}
+}
+
+func TestLoad_ReadinessConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("READINESS_CACHE_TTL")
+		os.Unsetenv("READINESS_ZENN_REQUIRED")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.ReadinessConfig{CacheTTL: 10 * time.Second, ProbeTimeout: 3 * time.Second}
+	if cfg.Readiness != want {
+		t.Errorf("Expected default readiness config %+v, got: %+v", want, cfg.Readiness)
+	}
+
+	os.Setenv("READINESS_CACHE_TTL", "0s")
+	os.Setenv("READINESS_ZENN_REQUIRED", "true")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.Readiness.CacheTTL != 0 {
+		t.Errorf("Expected readiness cache to be disabled, got: %v", cfg.Readiness.CacheTTL)
+	}
+	if !cfg.Readiness.ZennRequired {
+		t.Error("Expected Zenn to be required")
+	}
+}
+
+func TestLoad_TracingConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("TRACING_EXPORTER")
+		os.Unsetenv("TRACING_SAMPLE_RATIO")
+		os.Unsetenv("OTEL_SERVICE_NAME")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.TracingConfig{Exporter: "none", ServiceName: "nerine", SampleRatio: 1}
+	if cfg.Tracing != want {
+		t.Errorf("Expected default tracing config %+v, got: %+v", want, cfg.Tracing)
+	}
+
+	os.Setenv("TRACING_EXPORTER", "otlp")
+	os.Setenv("TRACING_SAMPLE_RATIO", "0.1")
+	os.Setenv("OTEL_SERVICE_NAME", "nerine-staging")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want = config.TracingConfig{Exporter: "otlp", ServiceName: "nerine-staging", SampleRatio: 0.1}
+	if cfg.Tracing != want {
+		t.Errorf("Expected tracing config %+v, got: %+v", want, cfg.Tracing)
+	}
+}
+
+// Hex-encoded SHA-256 digests of "test-key" and "other-key".
+const (
+	testKeyDigest  = "62af8704764faf8ea82fc61ce9c4c3908b6cb97d463a634e9e587d7c885db0ef"
+	otherKeyDigest = "580843d03d2216ff1a275d0991bad66e4d1af871171d929e9de604b7959f9bca"
+)
+
+func TestLoad_APIKeys(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEYS", `[
+		{"name":"web","sha256":"`+testKeyDigest+`","scopes":["read","preview"],"rateLimit":"6000/1h"},
+		{"name":"ops","sha256":"`+strings.ToUpper(otherKeyDigest)+`","scopes":["admin"],"expiresAt":"2026-01-01T00:00:00Z"}
+	]`)
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEYS")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := []config.APIKeyConfig{
+		{Name: "web", SHA256: sha256.Sum256([]byte("test-key")), Scopes: []string{"read", "preview"}, RateLimit: &config.RateLimit{Requests: 6000, Period: time.Hour}},
+		{Name: "ops", SHA256: sha256.Sum256([]byte("other-key")), Scopes: []string{"admin"}, ExpiresAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(cfg.APIKeys, want) {
+		t.Errorf("Expected API keys %+v, got: %+v", want, cfg.APIKeys)
+	}
+	if cfg.NerineAPIKey != "" {
+		t.Errorf("Expected no NerineAPIKey, got: %s", cfg.NerineAPIKey)
+	}
+}
+
+func TestLoad_RateLimitConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("RATE_LIMIT_ENABLED")
+		os.Unsetenv("RATE_LIMIT_KEY")
+		os.Unsetenv("RATE_LIMIT_IP")
+		os.Unsetenv("RATE_LIMIT_SCOPE_PREVIEW")
+		os.Unsetenv("RATE_LIMIT_SCOPE_ADMIN")
+		os.Unsetenv("RATE_LIMIT_STORE")
+		os.Unsetenv("RATE_LIMIT_REDIS_URL")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.RateLimitConfig{
+		Enabled: true,
+		Key:     config.RateLimit{Requests: 600, Period: time.Minute},
+		IP:      config.RateLimit{Requests: 60, Period: time.Minute},
+		Scopes:  map[string]config.RateLimit{},
+		Store:   "memory",
+	}
+	if !reflect.DeepEqual(cfg.RateLimit, want) {
+		t.Errorf("Expected default rate limit config %+v, got: %+v", want, cfg.RateLimit)
+	}
+
+	os.Setenv("RATE_LIMIT_ENABLED", "false")
+	os.Setenv("RATE_LIMIT_KEY", "100/10s")
+	os.Setenv("RATE_LIMIT_IP", "unlimited")
+	os.Setenv("RATE_LIMIT_SCOPE_PREVIEW", "30/1m")
+	os.Setenv("RATE_LIMIT_SCOPE_ADMIN", "unlimited")
+	os.Setenv("RATE_LIMIT_STORE", "redis")
+	os.Setenv("RATE_LIMIT_REDIS_URL", "redis://localhost:6379/0")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want = config.RateLimitConfig{
+		Key: config.RateLimit{Requests: 100, Period: 10 * time.Second},
+		Scopes: map[string]config.RateLimit{
+			"preview": {Requests: 30, Period: time.Minute},
+			"admin":   {},
+		},
+		Store:    "redis",
+		RedisURL: "redis://localhost:6379/0",
+	}
+	if !reflect.DeepEqual(cfg.RateLimit, want) {
+		t.Errorf("Expected rate limit config %+v, got: %+v", want, cfg.RateLimit)
+	}
+
+	os.Setenv("RATE_LIMIT_REDIS_URL", "localhost:6379")
+	_, err = config.Load()
+	if err == nil || err.Error() != "RATE_LIMIT_REDIS_URL must be a redis:// or rediss:// URL" {
+		t.Errorf("Expected an invalid Redis URL error, got: %v", err)
+	}
+}
+
+func TestLoad_PopularityConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("POPULARITY_WINDOW")
+		os.Unsetenv("POPULARITY_HALF_LIFE")
+		os.Unsetenv("POPULARITY_DEDUP_WINDOW")
+		os.Unsetenv("POPULARITY_STORE")
+		os.Unsetenv("POPULARITY_REDIS_URL")
+		os.Unsetenv("POPULARITY_SNAPSHOT_PATH")
+		os.Unsetenv("POPULARITY_SNAPSHOT_INTERVAL")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.PopularityConfig{
+		Window:           7 * 24 * time.Hour,
+		HalfLife:         24 * time.Hour,
+		DedupWindow:      30 * time.Minute,
+		Store:            "memory",
+		SnapshotInterval: time.Minute,
+	}
+	if cfg.Popularity != want {
+		t.Errorf("Expected popularity config %+v, got: %+v", want, cfg.Popularity)
+	}
+
+	os.Setenv("POPULARITY_WINDOW", "72h")
+	os.Setenv("POPULARITY_HALF_LIFE", "12h")
+	os.Setenv("POPULARITY_DEDUP_WINDOW", "0s")
+	os.Setenv("POPULARITY_STORE", "redis")
+	os.Setenv("POPULARITY_REDIS_URL", "redis://localhost:6379/1")
+	os.Setenv("POPULARITY_SNAPSHOT_PATH", "/var/lib/nerine/popularity.json")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want = config.PopularityConfig{
+		Window:           72 * time.Hour,
+		HalfLife:         12 * time.Hour,
+		Store:            "redis",
+		RedisURL:         "redis://localhost:6379/1",
+		SnapshotPath:     "/var/lib/nerine/popularity.json",
+		SnapshotInterval: time.Minute,
+	}
+	if cfg.Popularity != want {
+		t.Errorf("Expected popularity config %+v, got: %+v", want, cfg.Popularity)
+	}
+
+	os.Setenv("POPULARITY_REDIS_URL", "localhost:6379")
+	_, err = config.Load()
+	if err == nil || err.Error() != "POPULARITY_REDIS_URL must be a redis:// or rediss:// URL" {
+		t.Errorf("Expected the Redis URL to be rejected, got: %v", err)
+	}
+}
+
+func TestLoad_PreviewConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("PREVIEW_TOKEN_SECRET")
+		os.Unsetenv("PREVIEW_TOKEN_TTL")
+		os.Unsetenv("PREVIEW_TOKEN_MAX_TTL")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.PreviewConfig{TokenTTL: 24 * time.Hour, MaxTokenTTL: 7 * 24 * time.Hour}
+	if cfg.Preview != want {
+		t.Errorf("Expected preview config %+v, got: %+v", want, cfg.Preview)
+	}
+
+	os.Setenv("PREVIEW_TOKEN_SECRET", "0123456789abcdef0123456789abcdef")
+	os.Setenv("PREVIEW_TOKEN_TTL", "1h")
+	os.Setenv("PREVIEW_TOKEN_MAX_TTL", "1h")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want = config.PreviewConfig{TokenSecret: "0123456789abcdef0123456789abcdef", TokenTTL: time.Hour, MaxTokenTTL: time.Hour}
+	if cfg.Preview != want {
+		t.Errorf("Expected preview config %+v, got: %+v", want, cfg.Preview)
+	}
+}
+
+func TestLoad_CORSConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("CORS_PROFILE")
+		os.Unsetenv("CORS_ALLOWED_ORIGINS")
+		os.Unsetenv("CORS_ALLOWED_HEADERS")
+		os.Unsetenv("CORS_ALLOW_CREDENTIALS")
+		os.Unsetenv("CORS_MAX_AGE")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.CORS.Profile != "production" || len(cfg.CORS.AllowOrigins) != 0 {
+		t.Errorf("Expected the production profile without origins by default, got: %+v", cfg.CORS)
+	}
+	if !strings.Contains(strings.Join(cfg.CORS.AllowHeaders, ","), "X-API-Key") {
+		t.Errorf("Expected X-API-Key in the default allowed headers, got: %v", cfg.CORS.AllowHeaders)
+	}
+	if cfg.CORS.MaxAge != 10*time.Minute {
+		t.Errorf("Expected default max age 10m, got: %v", cfg.CORS.MaxAge)
+	}
+
+	os.Setenv("CORS_PROFILE", "development")
+	os.Setenv("CORS_ALLOWED_ORIGINS", "https://example.com, https://*.example.com")
+	os.Setenv("CORS_ALLOWED_HEADERS", "X-API-Key")
+	os.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	os.Setenv("CORS_MAX_AGE", "1h")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.CORSConfig{
+		Profile:          "development",
+		AllowOrigins:     []string{"https://example.com", "https://*.example.com", "http://localhost:*", "http://127.0.0.1:*"},
+		AllowHeaders:     []string{"X-API-Key"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}
+	if !reflect.DeepEqual(cfg.CORS, want) {
+		t.Errorf("Expected CORS config %+v, got: %+v", want, cfg.CORS)
+	}
+
+	os.Setenv("CORS_PROFILE", "open")
+	_, err = config.Load()
+	if err == nil || err.Error() != "CORS_ALLOW_CREDENTIALS cannot be used while every origin is allowed" {
+		t.Errorf("Expected credentials to be rejected with the open profile, got: %v", err)
+	}
+
+	os.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.CORS.AllowOrigins, []string{"*"}) {
+		t.Errorf("Expected every origin with the open profile, got: %v", cfg.CORS.AllowOrigins)
+	}
+}
+
+func TestLoad_LogConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("LOG_LEVEL")
+		os.Unsetenv("LOG_FORMAT")
+		os.Unsetenv("LOG_SAMPLING_INITIAL")
+		os.Unsetenv("LOG_FILE")
+		os.Unsetenv("LOG_FILE_MAX_BACKUPS")
+		os.Unsetenv("LOG_FILE_COMPRESS")
+		os.Unsetenv("LOG_LEVEL_ENDPOINT_ENABLED")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := config.LogConfig{
+		Level:    "info",
+		Format:   "json",
+		Sampling: config.LogSamplingConfig{Initial: 100, Thereafter: 100},
+		File:     config.LogFileConfig{MaxSizeMB: 100, MaxBackups: 7, MaxAgeDays: 30},
+	}
+	if cfg.Log != want {
+		t.Errorf("Expected default log config %+v, got: %+v", want, cfg.Log)
+	}
+
+	os.Setenv("LOG_LEVEL", "debug")
+	os.Setenv("LOG_FORMAT", "console")
+	os.Setenv("LOG_SAMPLING_INITIAL", "0")
+	os.Setenv("LOG_FILE", "/var/log/nerine.log")
+	os.Setenv("LOG_FILE_MAX_BACKUPS", "3")
+	os.Setenv("LOG_FILE_COMPRESS", "true")
+	os.Setenv("LOG_LEVEL_ENDPOINT_ENABLED", "true")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want = config.LogConfig{
+		Level:    "debug",
+		Format:   "console",
+		Sampling: config.LogSamplingConfig{Initial: 0, Thereafter: 100},
+		File: config.LogFileConfig{
+			Path:       "/var/log/nerine.log",
+			MaxSizeMB:  100,
+			MaxBackups: 3,
+			MaxAgeDays: 30,
+			Compress:   true,
+		},
+		LevelEndpointEnabled: true,
+	}
+	if cfg.Log != want {
+		t.Errorf("Expected log config %+v, got: %+v", want, cfg.Log)
+	}
+}
+
+func TestLoad_CircuitBreakerConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+	os.Setenv("CIRCUIT_BREAKER_FAILURE_RATIO", "0.25")
+	os.Setenv("CIRCUIT_BREAKER_COOLDOWN", "10s")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("CIRCUIT_BREAKER_FAILURE_RATIO")
+		os.Unsetenv("CIRCUIT_BREAKER_COOLDOWN")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := config.CircuitBreakerConfig{
+		FailureRatio:     0.25,
+		MinRequests:      10,
+		Interval:         time.Minute,
+		Cooldown:         10 * time.Second,
+		HalfOpenRequests: 1,
+	}
+	if cfg.CircuitBreaker != expected {
+		t.Errorf("Expected circuit breaker config %+v, got: %+v", expected, cfg.CircuitBreaker)
+	}
+}
+
+func TestLoad_RetryConfig(t *testing.T) {
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+	os.Setenv("ZENN_RETRY_MAX_ATTEMPTS", "5")
+	os.Setenv("ZENN_RETRY_MAX_DELAY", "10s")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+		os.Unsetenv("ZENN_RETRY_MAX_ATTEMPTS")
+		os.Unsetenv("ZENN_RETRY_MAX_DELAY")
+	}()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	defaults := config.RetryConfig{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second}
+	if cfg.MicroCMSRetry != defaults {
+		t.Errorf("Expected microCMS retry config %+v, got: %+v", defaults, cfg.MicroCMSRetry)
+	}
+	zenn := config.RetryConfig{MaxAttempts: 5, BaseDelay: 200 * time.Millisecond, MaxDelay: 10 * time.Second}
+	if cfg.ZennRetry != zenn {
+		t.Errorf("Expected Zenn retry config %+v, got: %+v", zenn, cfg.ZennRetry)
+	}
+}
+
+func TestLoad_InvalidCacheConfig(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		errorMsg string
+	}{
+		{
+			name:     "Invalid duration",
+			key:      "CACHE_TTL_ARTICLE",
+			value:    "five minutes",
+			errorMsg: "CACHE_TTL_ARTICLE must be a duration",
+		},
+		{
+			name:     "Negative duration",
+			key:      "CACHE_NEGATIVE_TTL",
+			value:    "-1s",
+			errorMsg: "CACHE_NEGATIVE_TTL must be a non-negative duration",
+		},
+		{
+			name:     "Invalid boolean",
+			key:      "CACHE_ENABLED",
+			value:    "maybe",
+			errorMsg: "CACHE_ENABLED must be a boolean",
+		},
+		{
+			name:     "Zero max entries",
+			key:      "CACHE_MAX_ENTRIES",
+			value:    "0",
+			errorMsg: "CACHE_MAX_ENTRIES must be greater than 0",
+		},
+		{
+			name:     "Zero retry attempts",
+			key:      "MICROCMS_RETRY_MAX_ATTEMPTS",
+			value:    "0",
+			errorMsg: "MICROCMS_RETRY_MAX_ATTEMPTS must be greater than 0",
+		},
+		{
+			name:     "Invalid failure ratio",
+			key:      "CIRCUIT_BREAKER_FAILURE_RATIO",
+			value:    "half",
+			errorMsg: "CIRCUIT_BREAKER_FAILURE_RATIO must be a number",
+		},
+		{
+			name:     "Failure ratio out of range",
+			key:      "CIRCUIT_BREAKER_FAILURE_RATIO",
+			value:    "1.5",
+			errorMsg: "CIRCUIT_BREAKER_FAILURE_RATIO must be greater than 0 and at most 1",
+		},
+		{
+			name:     "Zero half-open requests",
+			key:      "CIRCUIT_BREAKER_HALF_OPEN_REQUESTS",
+			value:    "0",
+			errorMsg: "CIRCUIT_BREAKER_HALF_OPEN_REQUESTS must be greater than 0",
+		},
+		{
+			name:     "Unknown tracing exporter",
+			key:      "TRACING_EXPORTER",
+			value:    "jaeger",
+			errorMsg: `TRACING_EXPORTER must be one of none, stdout or otlp, got "jaeger"`,
+		},
+		{
+			name:     "Sample ratio out of range",
+			key:      "TRACING_SAMPLE_RATIO",
+			value:    "1.5",
+			errorMsg: "TRACING_SAMPLE_RATIO must be between 0 and 1",
+		},
+		{
+			name:     "API keys not JSON",
+			key:      "NERINE_API_KEYS",
+			value:    "web:secret",
+			errorMsg: "NERINE_API_KEYS must be a JSON array of keys",
+		},
+		{
+			name:     "API key with a plain-text key",
+			key:      "NERINE_API_KEYS",
+			value:    `[{"name":"web","sha256":"secret","scopes":["read"]}]`,
+			errorMsg: `NERINE_API_KEYS key "web" must have a hex-encoded SHA-256 digest`,
+		},
+		{
+			name:     "API key with an unknown scope",
+			key:      "NERINE_API_KEYS",
+			value:    `[{"name":"web","sha256":"` + testKeyDigest + `","scopes":["write"]}]`,
+			errorMsg: `NERINE_API_KEYS key "web" has unknown scope "write", must be read, preview or admin`,
+		},
+		{
+			name:     "API key without scopes",
+			key:      "NERINE_API_KEYS",
+			value:    `[{"name":"web","sha256":"` + testKeyDigest + `"}]`,
+			errorMsg: `NERINE_API_KEYS key "web" must have at least one scope`,
+		},
+		{
+			name:     "API keys with the same digest",
+			key:      "NERINE_API_KEYS",
+			value:    `[{"name":"web","sha256":"` + testKeyDigest + `","scopes":["read"]},{"name":"ops","sha256":"` + testKeyDigest + `","scopes":["admin"]}]`,
+			errorMsg: `NERINE_API_KEYS key "ops" has the same digest as another key`,
+		},
+		{
+			name:     "API keys with the same name",
+			key:      "NERINE_API_KEYS",
+			value:    `[{"name":"web","sha256":"` + testKeyDigest + `","scopes":["read"]},{"name":"web","sha256":"` + testKeyDigest + `","scopes":["read"]}]`,
+			errorMsg: `NERINE_API_KEYS has more than one key named "web"`,
+		},
+		{
+			name:     "API key with an invalid rate limit",
+			key:      "NERINE_API_KEYS",
+			value:    `[{"name":"web","sha256":"` + testKeyDigest + `","scopes":["read"],"rateLimit":"600"}]`,
+			errorMsg: `NERINE_API_KEYS key "web" must have a rate limit such as 600/1m`,
+		},
+		{
+			name:     "Invalid rate limit",
+			key:      "RATE_LIMIT_KEY",
+			value:    "600 per minute",
+			errorMsg: "RATE_LIMIT_KEY must be a rate limit such as 600/1m or unlimited",
+		},
+		{
+			name:     "Zero rate limit",
+			key:      "RATE_LIMIT_SCOPE_READ",
+			value:    "0/1m",
+			errorMsg: "RATE_LIMIT_SCOPE_READ must be a rate limit such as 600/1m or unlimited",
+		},
+		{
+			name:     "Redis store without URL",
+			key:      "RATE_LIMIT_STORE",
+			value:    "redis",
+			errorMsg: "RATE_LIMIT_REDIS_URL is required when RATE_LIMIT_STORE is redis",
+		},
+		{
+			name:     "Unknown rate limit store",
+			key:      "RATE_LIMIT_STORE",
+			value:    "memcached",
+			errorMsg: `RATE_LIMIT_STORE must be memory or redis, got "memcached"`,
+		},
+		{
+			name:     "Popularity window below the bucket size",
+			key:      "POPULARITY_WINDOW",
+			value:    "30m",
+			errorMsg: "POPULARITY_WINDOW must be at least 1h",
+		},
+		{
+			name:     "Zero popularity half-life",
+			key:      "POPULARITY_HALF_LIFE",
+			value:    "0s",
+			errorMsg: "POPULARITY_HALF_LIFE must be positive",
+		},
+		{
+			name:     "Negative popularity dedup window",
+			key:      "POPULARITY_DEDUP_WINDOW",
+			value:    "-1m",
+			errorMsg: "POPULARITY_DEDUP_WINDOW must be a non-negative duration",
+		},
+		{
+			name:     "Unknown popularity store",
+			key:      "POPULARITY_STORE",
+			value:    "postgres",
+			errorMsg: `POPULARITY_STORE must be memory or redis, got "postgres"`,
+		},
+		{
+			name:     "Popularity redis store without URL",
+			key:      "POPULARITY_STORE",
+			value:    "redis",
+			errorMsg: "POPULARITY_REDIS_URL is required when POPULARITY_STORE is redis",
+		},
+		{
+			name:     "Short preview token secret",
+			key:      "PREVIEW_TOKEN_SECRET",
+			value:    "short",
+			errorMsg: "PREVIEW_TOKEN_SECRET must be at least 32 characters",
+		},
+		{
+			name:     "Zero preview token TTL",
+			key:      "PREVIEW_TOKEN_TTL",
+			value:    "0s",
+			errorMsg: "PREVIEW_TOKEN_TTL must be positive",
+		},
+		{
+			name:     "Preview token TTL above the maximum",
+			key:      "PREVIEW_TOKEN_TTL",
+			value:    "720h",
+			errorMsg: "PREVIEW_TOKEN_MAX_TTL must not be shorter than PREVIEW_TOKEN_TTL",
+		},
+		{
+			name:     "Unknown CORS profile",
+			key:      "CORS_PROFILE",
+			value:    "staging",
+			errorMsg: `CORS_PROFILE must be one of production, development or open, got "staging"`,
+		},
+		{
+			name:     "CORS origin with a path",
+			key:      "CORS_ALLOWED_ORIGINS",
+			value:    "https://example.com/app",
+			errorMsg: `CORS_ALLOWED_ORIGINS must list origins such as https://example.com or https://*.example.com, got "https://example.com/app"`,
+		},
+		{
+			name:     "CORS origin without scheme",
+			key:      "CORS_ALLOWED_ORIGINS",
+			value:    "example.com",
+			errorMsg: `CORS_ALLOWED_ORIGINS must list origins such as https://example.com or https://*.example.com, got "example.com"`,
+		},
+		{
+			name:     "Invalid trusted proxy",
+			key:      "TRUSTED_PROXIES",
+			value:    "10.0.0.0/8,proxy.internal",
+			errorMsg: `TRUSTED_PROXIES must list CIDRs such as 10.0.0.0/8 or addresses, got "proxy.internal"`,
+		},
+		{
+			name:     "Unknown log level",
+			key:      "LOG_LEVEL",
+			value:    "verbose",
+			errorMsg: `LOG_LEVEL must be one of debug, info, warn or error, got "verbose"`,
+		},
+		{
+			name:     "Unknown log format",
+			key:      "LOG_FORMAT",
+			value:    "logfmt",
+			errorMsg: `LOG_FORMAT must be json or console, got "logfmt"`,
+		},
+		{
+			name:     "Zero sampling thereafter",
+			key:      "LOG_SAMPLING_THEREAFTER",
+			value:    "0",
+			errorMsg: "LOG_SAMPLING_THEREAFTER must be greater than 0",
+		},
+		{
+			name:     "Zero microCMS timeout",
+			key:      "MICROCMS_TIMEOUT",
+			value:    "0s",
+			errorMsg: "MICROCMS_TIMEOUT must be greater than 0",
+		},
+		{
+			name:     "Zero probe timeout",
+			key:      "READINESS_PROBE_TIMEOUT",
+			value:    "0s",
+			errorMsg: "READINESS_PROBE_TIMEOUT must be greater than 0",
+		},
+	}
+
+	os.Setenv("MICROCMS_API_KEY", "test-microcms-key")
+	os.Setenv("MICROCMS_SERVICE_ID", "test-service-id")
+	os.Setenv("NERINE_API_KEY", "test-nerine-key")
+
+	defer func() {
+		os.Unsetenv("MICROCMS_API_KEY")
+		os.Unsetenv("MICROCMS_SERVICE_ID")
+		os.Unsetenv("NERINE_API_KEY")
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(tt.key, tt.value)
+			defer os.Unsetenv(tt.key)
+
+			cfg, err := config.Load()
+
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if cfg != nil {
+				t.Error("Expected cfg to be nil when loading fails")
+			}
+			if !strings.HasPrefix(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error message to start with '%s', got: %s", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
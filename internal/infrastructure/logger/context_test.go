@@ -0,0 +1,36 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zap.InfoLevel)
+	requestLogger := zap.New(core).With(zap.String("request_id", "req-1"))
+
+	ctx := logger.NewContext(context.Background(), requestLogger)
+	logger.FromContext(ctx).Info("fetched articles")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 log entry, got: %d", len(entries))
+	}
+	if got := entries[0].ContextMap()["request_id"]; got != "req-1" {
+		t.Errorf("Expected the request fields on the entry, got: %v", got)
+	}
+}
+
+func TestFromContext_FallsBackToGlobalLogger(t *testing.T) {
+	t.Parallel()
+
+	if got := logger.FromContext(context.Background()); got != zap.L() {
+		t.Error("Expected the global logger without a request logger")
+	}
+}
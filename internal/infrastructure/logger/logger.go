@@ -1,9 +1,113 @@
 package logger
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-func New() (*zap.Logger, error) {
-	return zap.NewProduction()
+// Format is the encoding of log entries.
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatConsole Format = "console"
+)
+
+// Config describes the logger. The zero value logs JSON at info level to
+// stderr without sampling.
+type Config struct {
+	// Level is a zap level name such as "debug" or "warn". Empty means info.
+	Level    string
+	Format   Format
+	Sampling SamplingConfig
+	File     FileConfig
+}
+
+// SamplingConfig limits repeated entries: every second, the first Initial
+// entries with the same level and message are logged, then every Thereafter-th.
+// A zero Initial disables sampling.
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+}
+
+// FileConfig writes logs to Path instead of stderr. The file is rotated once
+// it reaches MaxSizeMB, keeping at most MaxBackups old files for MaxAgeDays.
+type FileConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// New builds the logger described by cfg. The returned level controls the
+// logger and every logger derived from it, so it can be changed at runtime.
+func New(cfg Config) (*zap.Logger, zap.AtomicLevel, error) {
+	level, err := zap.ParseAtomicLevel(cfg.Level)
+	if err != nil {
+		return nil, zap.AtomicLevel{}, fmt.Errorf("failed to parse log level: %w", err)
+	}
+
+	encoder, err := newEncoder(cfg.Format)
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	output, err := newOutput(cfg.File)
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
+	}
+
+	core := zapcore.NewCore(encoder, output, level)
+	if cfg.Sampling.Initial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
+
+	logger := zap.New(core,
+		zap.AddCaller(),
+		zap.AddStacktrace(zap.ErrorLevel),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	)
+	return logger, level, nil
+}
+
+func newEncoder(format Format) (zapcore.Encoder, error) {
+	switch format {
+	case "", FormatJSON:
+		return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), nil
+	case FormatConsole:
+		encoderConfig := zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+func newOutput(cfg FileConfig) (zapcore.WriteSyncer, error) {
+	if cfg.Path == "" {
+		return zapcore.Lock(os.Stderr), nil
+	}
+
+	// lumberjack opens the file on the first write; open it now so that a
+	// wrong path or permission fails at startup instead of losing logs.
+	f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	f.Close()
+
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+	}), nil
 }
@@ -1,6 +1,9 @@
 package logger_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kozennoki/nerine/internal/infrastructure/logger"
@@ -10,7 +13,7 @@ import (
 func TestNew(t *testing.T) {
 	t.Parallel()
 
-	logger, err := logger.New()
+	logger, _, err := logger.New(logger.Config{})
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
@@ -39,7 +42,7 @@ func TestNew(t *testing.T) {
 func TestNew_ProducesProductionLogger(t *testing.T) {
 	t.Parallel()
 
-	logger, err := logger.New()
+	logger, _, err := logger.New(logger.Config{})
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
@@ -58,3 +61,154 @@ func TestNew_ProducesProductionLogger(t *testing.T) {
 	)
 	logger.Sync()
 }
+
+func TestNew_LevelAndFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   logger.Config
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "JSON at info level by default",
+			config:   logger.Config{},
+			contains: []string{`"level":"info"`, `"msg":"info message"`},
+			excludes: []string{"debug message"},
+		},
+		{
+			name:     "Console at debug level",
+			config:   logger.Config{Level: "debug", Format: logger.FormatConsole},
+			contains: []string{"DEBUG\tlogger/logger_test.go", "debug message", "INFO\t"},
+			excludes: []string{`"msg"`},
+		},
+		{
+			name:     "Warn level drops info",
+			config:   logger.Config{Level: "warn"},
+			excludes: []string{"debug message", "info message"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "nerine.log")
+			tt.config.File = logger.FileConfig{Path: path}
+			l, _, err := logger.New(tt.config)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			l.Debug("debug message")
+			l.Info("info message")
+			l.Sync()
+
+			output := readFile(t, path)
+			for _, s := range tt.contains {
+				if !strings.Contains(output, s) {
+					t.Errorf("Expected output to contain %q, got: %s", s, output)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(output, s) {
+					t.Errorf("Expected output not to contain %q, got: %s", s, output)
+				}
+			}
+		})
+	}
+}
+
+func TestNew_AtomicLevel(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nerine.log")
+	l, level, err := logger.New(logger.Config{File: logger.FileConfig{Path: path}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	child := l.With(zap.String("request_id", "abc"))
+	child.Debug("before")
+	level.SetLevel(zap.DebugLevel)
+	child.Debug("after")
+	l.Sync()
+
+	output := readFile(t, path)
+	if strings.Contains(output, "before") {
+		t.Errorf("Expected debug entry before the level change to be dropped, got: %s", output)
+	}
+	if !strings.Contains(output, "after") {
+		t.Errorf("Expected debug entry after the level change, got: %s", output)
+	}
+}
+
+func TestNew_Sampling(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nerine.log")
+	l, _, err := logger.New(logger.Config{
+		Sampling: logger.SamplingConfig{Initial: 2, Thereafter: 3},
+		File:     logger.FileConfig{Path: path},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for i := 0; i < 8; i++ {
+		l.Info("repeated")
+	}
+	l.Sync()
+
+	// The first 2 entries, then the 5th and the 8th
+	if got := strings.Count(readFile(t, path), "repeated"); got != 4 {
+		t.Errorf("Expected 4 sampled entries, got: %d", got)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   logger.Config
+		errorMsg string
+	}{
+		{
+			name:     "Unknown level",
+			config:   logger.Config{Level: "verbose"},
+			errorMsg: "failed to parse log level",
+		},
+		{
+			name:     "Unknown format",
+			config:   logger.Config{Format: "logfmt"},
+			errorMsg: `unknown log format "logfmt"`,
+		},
+		{
+			name:     "Unwritable file",
+			config:   logger.Config{File: logger.FileConfig{Path: filepath.Join(os.DevNull, "nerine.log")}},
+			errorMsg: "failed to open log file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := logger.New(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("Expected error containing %q, got: %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	return string(b)
+}
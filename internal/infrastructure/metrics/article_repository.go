@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type articleRepository struct {
+	next    repository.ArticleRepository
+	metrics *Metrics
+	source  string
+}
+
+// NewArticleRepository records every call to next as an upstream call to source.
+func NewArticleRepository(
+	next repository.ArticleRepository,
+	metrics *Metrics,
+	source string,
+) repository.ArticleRepository {
+	return &articleRepository{
+		next:    next,
+		metrics: metrics,
+		source:  source,
+	}
+}
+
+func (r *articleRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return observe(r.metrics, r.source, "GetArticles", func() (repository.ArticlePage, error) {
+		return r.next.GetArticles(ctx, limit, offset, fields)
+	})
+}
+
+func (r *articleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
+	return observe(r.metrics, r.source, "GetArticleByID", func() (*entity.Article, error) {
+		return r.next.GetArticleByID(ctx, id)
+	})
+}
+
+func (r *articleRepository) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return observe(r.metrics, r.source, "GetArticlesByIDs", func() ([]*entity.Article, error) {
+		return r.next.GetArticlesByIDs(ctx, ids, fields)
+	})
+}
+
+func (r *articleRepository) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
+	return observe(r.metrics, r.source, "GetDraftArticleByID", func() (*entity.Article, error) {
+		return r.next.GetDraftArticleByID(ctx, id, draftKey)
+	})
+}
+
+func (r *articleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return observe(r.metrics, r.source, "GetArticlesByCategory", func() (repository.ArticlePage, error) {
+		return r.next.GetArticlesByCategory(ctx, categorySlug, limit, offset, fields)
+	})
+}
+
+func (r *articleRepository) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return observe(r.metrics, r.source, "GetPopularArticles", func() ([]*entity.Article, error) {
+		return r.next.GetPopularArticles(ctx, limit, fields)
+	})
+}
+
+func (r *articleRepository) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return observe(r.metrics, r.source, "GetLatestArticles", func() ([]*entity.Article, error) {
+		return r.next.GetLatestArticles(ctx, limit, fields)
+	})
+}
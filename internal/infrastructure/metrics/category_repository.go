@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type categoryRepository struct {
+	next    repository.CategoryRepository
+	metrics *Metrics
+	source  string
+}
+
+// NewCategoryRepository records every call to next as an upstream call to source.
+func NewCategoryRepository(
+	next repository.CategoryRepository,
+	metrics *Metrics,
+	source string,
+) repository.CategoryRepository {
+	return &categoryRepository{
+		next:    next,
+		metrics: metrics,
+		source:  source,
+	}
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context) ([]*entity.Category, error) {
+	return observe(r.metrics, r.source, "GetCategories", func() ([]*entity.Category, error) {
+		return r.next.GetCategories(ctx)
+	})
+}
+
+func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	return observe(r.metrics, r.source, "GetCategoryBySlug", func() (*entity.Category, error) {
+		return r.next.GetCategoryBySlug(ctx, slug)
+	})
+}
@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "nerine"
+
+// Upstream call results, used as the result label.
+const (
+	ResultOK                  = "ok"
+	ResultNotFound            = "not_found"
+	ResultInvalidArgument     = "invalid_argument"
+	ResultRateLimited         = "rate_limited"
+	ResultUpstreamUnavailable = "upstream_unavailable"
+	ResultCanceled            = "canceled"
+	ResultError               = "error"
+)
+
+// Metrics holds the Prometheus collectors of the server on a registry of its
+// own, so that tests can create as many as they need.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests     *prometheus.CounterVec
+	httpDuration     *prometheus.HistogramVec
+	upstreamRequests *prometheus.CounterVec
+	upstreamDuration *prometheus.HistogramVec
+	authFailures     *prometheus.CounterVec
+	apiKeyRequests   *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		upstreamRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_requests_total",
+			Help:      "Repository calls that reached an upstream, by source, method and result.",
+		}, []string{"source", "method", "result"}),
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Latency of repository calls that reached an upstream, by source and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"source", "method"}),
+		authFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_failures_total",
+			Help:      "Rejected requests by authentication scheme and reason.",
+		}, []string{"scheme", "reason"}),
+		apiKeyRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_key_requests_total",
+			Help:      "Requests by the name of the API key presented and status.",
+		}, []string{"api_key", "status"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.upstreamRequests,
+		m.upstreamDuration,
+		m.authFailures,
+		m.apiKeyRequests,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveUpstreamCall(source, method string, err error, duration time.Duration) {
+	m.upstreamRequests.WithLabelValues(source, method, result(err)).Inc()
+	m.upstreamDuration.WithLabelValues(source, method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) AuthFailure(scheme, reason string) {
+	m.authFailures.WithLabelValues(scheme, reason).Inc()
+}
+
+// ObserveAPIKeyRequest counts a request made with the API key called name,
+// which shows whether an old key is still used during a rotation.
+func (m *Metrics) ObserveAPIKeyRequest(name string, status int) {
+	m.apiKeyRequests.WithLabelValues(name, strconv.Itoa(status)).Inc()
+}
+
+// RegisterCache exports the counters of store.
+func (m *Metrics) RegisterCache(store *cache.Store) {
+	counter := func(name, help string, value func(cache.Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      name,
+			Help:      help,
+		}, func() float64 {
+			return float64(value(store.Stats()))
+		})
+	}
+
+	m.registry.MustRegister(
+		counter("hits_total", "Repository calls answered from fresh cache entries.", func(s cache.Stats) uint64 { return s.Hits }),
+		counter("misses_total", "Repository calls that had to reach the upstream.", func(s cache.Stats) uint64 { return s.Misses }),
+		counter("stale_served_total", "Repository calls answered with expired entries.", func(s cache.Stats) uint64 { return s.StaleServed }),
+		counter("evictions_total", "Entries evicted to stay within the size limit.", func(s cache.Stats) uint64 { return s.Evictions }),
+		counter("coalesced_total", "Calls that joined an identical upstream call in flight.", func(s cache.Stats) uint64 { return s.Coalesced }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "entries",
+			Help:      "Entries currently in the cache.",
+		}, func() float64 {
+			return float64(store.Len())
+		}),
+	)
+}
+
+// RegisterUpstreamMonitor exports the circuit breaker state of each upstream
+// reported by monitor.
+func (m *Metrics) RegisterUpstreamMonitor(monitor repository.UpstreamMonitor) {
+	m.registry.MustRegister(&circuitCollector{monitor: monitor})
+}
+
+var circuitStates = []entity.CircuitState{entity.CircuitClosed, entity.CircuitHalfOpen, entity.CircuitOpen}
+
+var circuitStateDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "circuit_breaker", "state"),
+	"Circuit breaker state of each upstream; 1 for the current state, 0 otherwise.",
+	[]string{"upstream", "state"}, nil,
+)
+
+// circuitCollector reads the breaker states when scraped.
+type circuitCollector struct {
+	monitor repository.UpstreamMonitor
+}
+
+func (c *circuitCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- circuitStateDesc
+}
+
+func (c *circuitCollector) Collect(ch chan<- prometheus.Metric) {
+	upstreams, err := c.monitor.GetUpstreamStatuses(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(circuitStateDesc, err)
+		return
+	}
+	for _, upstream := range upstreams {
+		for _, state := range circuitStates {
+			value := 0.0
+			if upstream.State == state {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(circuitStateDesc, prometheus.GaugeValue, value, upstream.Name, string(state))
+		}
+	}
+}
+
+func result(err error) string {
+	switch {
+	case err == nil:
+		return ResultOK
+	case errors.Is(err, repository.ErrNotFound):
+		return ResultNotFound
+	case errors.Is(err, repository.ErrInvalidArgument):
+		return ResultInvalidArgument
+	case errors.Is(err, repository.ErrRateLimited):
+		return ResultRateLimited
+	case errors.Is(err, repository.ErrUpstreamUnavailable):
+		return ResultUpstreamUnavailable
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ResultCanceled
+	default:
+		return ResultError
+	}
+}
+
+// observe times fn as a call to an upstream.
+func observe[T any](m *Metrics, source, method string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	v, err := fn()
+	m.ObserveUpstreamCall(source, method, err, time.Since(start))
+	return v, err
+}
@@ -0,0 +1,138 @@
+package metrics_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/kozennoki/nerine/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// scrape returns the metrics of m in the Prometheus text format.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMetrics_HTTPRequests(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/articles/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/articles/:id", http.StatusOK, 30*time.Millisecond)
+	m.AuthFailure("api_key", "invalid_api_key")
+	m.ObserveAPIKeyRequest("web", http.StatusOK)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `nerine_http_requests_total{method="GET",route="/api/v1/articles/:id",status="200"} 2`)
+	assert.Contains(t, body, `nerine_http_request_duration_seconds_count{method="GET",route="/api/v1/articles/:id",status="200"} 2`)
+	assert.Contains(t, body, `nerine_auth_failures_total{reason="invalid_api_key",scheme="api_key"} 1`)
+	assert.Contains(t, body, `nerine_api_key_requests_total{api_key="web",status="200"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestArticleRepository_ObservesUpstreamCalls(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "article-1").Return(&entity.Article{ID: "article-1"}, nil)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "missing").Return(nil, fmt.Errorf("failed to get article: %w", repository.ErrNotFound))
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 5, nil).Return(nil, fmt.Errorf("failed to get articles: %w", repository.ErrUpstreamUnavailable))
+
+	m := metrics.New()
+	repo := metrics.NewArticleRepository(mockRepo, m, "microcms")
+
+	article, err := repo.GetArticleByID(context.Background(), "article-1")
+	require.NoError(t, err)
+	assert.Equal(t, "article-1", article.ID)
+
+	_, err = repo.GetArticleByID(context.Background(), "missing")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	_, err = repo.GetLatestArticles(context.Background(), 5, nil)
+	assert.ErrorIs(t, err, repository.ErrUpstreamUnavailable)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `nerine_upstream_requests_total{method="GetArticleByID",result="ok",source="microcms"} 1`)
+	assert.Contains(t, body, `nerine_upstream_requests_total{method="GetArticleByID",result="not_found",source="microcms"} 1`)
+	assert.Contains(t, body, `nerine_upstream_requests_total{method="GetLatestArticles",result="upstream_unavailable",source="microcms"} 1`)
+	assert.Contains(t, body, `nerine_upstream_request_duration_seconds_count{method="GetArticleByID",source="microcms"} 2`)
+}
+
+func TestZennRepository_ObservesUpstreamCalls(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, nil).Return(repository.ArticlePage{}, context.Canceled)
+
+	m := metrics.New()
+	repo := metrics.NewZennRepository(mockRepo, m, "zenn")
+
+	_, err := repo.GetArticles(context.Background(), 10, 0, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Contains(t, scrape(t, m), `nerine_upstream_requests_total{method="GetArticles",result="canceled",source="zenn"} 1`)
+}
+
+func TestMetrics_RegisterCache(t *testing.T) {
+	t.Parallel()
+
+	store := cache.NewStore(1)
+	defer store.Close()
+
+	lifetime := cache.Lifetime{TTL: time.Minute}
+	store.Set("a", cache.Entry{Value: 1}, lifetime)
+	store.Set("b", cache.Entry{Value: 2}, lifetime)
+	store.Get("a")
+	store.Get("b")
+
+	m := metrics.New()
+	m.RegisterCache(store)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, "nerine_cache_hits_total 1")
+	assert.Contains(t, body, "nerine_cache_misses_total 1")
+	assert.Contains(t, body, "nerine_cache_evictions_total 1")
+	assert.Contains(t, body, "nerine_cache_entries 1")
+}
+
+func TestMetrics_RegisterUpstreamMonitor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	monitor := mocks.NewMockUpstreamMonitor(ctrl)
+	monitor.EXPECT().GetUpstreamStatuses(gomock.Any()).Return([]*entity.UpstreamStatus{
+		{Name: "microcms", State: entity.CircuitClosed},
+		{Name: "zenn", State: entity.CircuitOpen},
+	}, nil)
+
+	m := metrics.New()
+	m.RegisterUpstreamMonitor(monitor)
+
+	body := scrape(t, m)
+	for _, line := range []string{
+		`nerine_circuit_breaker_state{state="closed",upstream="microcms"} 1`,
+		`nerine_circuit_breaker_state{state="open",upstream="microcms"} 0`,
+		`nerine_circuit_breaker_state{state="open",upstream="zenn"} 1`,
+		`nerine_circuit_breaker_state{state="half-open",upstream="zenn"} 0`,
+	} {
+		assert.True(t, strings.Contains(body, line), "expected %q in:\n%s", line, body)
+	}
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type zennRepository struct {
+	next    repository.ArticleReader
+	metrics *Metrics
+	source  string
+}
+
+// NewZennRepository records every call to the Zenn article source.
+func NewZennRepository(
+	next repository.ArticleReader,
+	metrics *Metrics,
+	source string,
+) repository.ArticleReader {
+	return &zennRepository{
+		next:    next,
+		metrics: metrics,
+		source:  source,
+	}
+}
+
+func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return observe(r.metrics, r.source, "GetArticles", func() (repository.ArticlePage, error) {
+		return r.next.GetArticles(ctx, limit, offset, fields)
+	})
+}
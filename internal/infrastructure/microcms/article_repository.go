@@ -3,21 +3,22 @@ package microcms
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
 	"github.com/kozennoki/nerine/internal/domain/repository"
-	"github.com/microcmsio/microcms-go-sdk"
 )
 
+const articlesEndpoint = "blog"
+
 type articleRepository struct {
-	microCMS *microcms.Client
+	client *Client
 }
 
-func NewArticleRepository(apiKey, serviceID string) repository.ArticleRepository {
-	client := microcms.New(serviceID, apiKey)
+func NewArticleRepository(client *Client) repository.ArticleRepository {
 	return &articleRepository{
-		microCMS: client,
+		client: client,
 	}
 }
 
@@ -33,6 +34,19 @@ type article struct {
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
 
+// apiFields maps article fields to their microCMS API field IDs.
+var apiFields = map[entity.ArticleField]string{
+	entity.ArticleFieldID:          "id",
+	entity.ArticleFieldTitle:       "title",
+	entity.ArticleFieldImage:       "image",
+	entity.ArticleFieldCategory:    "category",
+	entity.ArticleFieldDescription: "description",
+	entity.ArticleFieldBody:        "body",
+	entity.ArticleFieldPublishedAt: "publishedAt",
+	entity.ArticleFieldCreatedAt:   "createdAt",
+	entity.ArticleFieldUpdatedAt:   "updatedAt",
+}
+
 type image struct {
 	URL    string `json:"url"`
 	Height int    `json:"height"`
@@ -46,170 +60,169 @@ type articleListResponse struct {
 	Limit      int       `json:"limit"`
 }
 
-func (r *articleRepository) GetArticles(ctx context.Context, limit, offset int) ([]*entity.Article, error) {
+func (r *articleRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
 	var res articleListResponse
-	params := microcms.ListParams{
-		Endpoint: "blog",
-		Limit:    limit,
-		Offset:   offset,
-		Orders:   []string{"-publishedAt"},
+	params := ListParams{
+		Limit:  limit,
+		Offset: offset,
+		Orders: []string{"-publishedAt"},
+		Fields: convertFields(fields),
 	}
 
-	err := r.microCMS.List(params, &res)
+	err := r.client.List(ctx, articlesEndpoint, params, &res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get articles: %w", err)
+		return repository.ArticlePage{}, wrapError("failed to get articles", err)
 	}
 
-	articles := make([]*entity.Article, len(res.Contents))
-	for i, item := range res.Contents {
-		articles[i] = &entity.Article{
-			ID:    item.ID,
-			Title: item.Title,
-			Image: item.Image.URL,
-			Category: entity.Category{
-				Slug: item.Category.ID,
-				Name: item.Category.Name,
-			},
-			Description: item.Description,
-			Body:        item.Body,
-			PublishedAt: item.PublishedAt,
-			CreatedAt:   item.CreatedAt,
-			UpdatedAt:   item.UpdatedAt,
-		}
-	}
-
-	return articles, nil
+	return convertToPage(res), nil
 }
 
 func (r *articleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
 	var res article
 
-	params := microcms.GetParams{
-		Endpoint:  "blog",
-		ContentID: id,
-	}
-	err := r.microCMS.Get(params, &res)
+	err := r.client.Get(ctx, articlesEndpoint, id, GetParams{}, &res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get article by ID: %w", err)
+		return nil, wrapError("failed to get article by ID", err)
 	}
 
-	return &entity.Article{
-		ID:    res.ID,
-		Title: res.Title,
-		Image: res.Image.URL,
-		Category: entity.Category{
-			Slug: res.Category.ID,
-			Name: res.Category.Name,
-		},
-		Description: res.Description,
-		Body:        res.Body,
-		PublishedAt: res.PublishedAt,
-		CreatedAt:   res.CreatedAt,
-		UpdatedAt:   res.UpdatedAt,
-	}, nil
+	return convertToEntity(res), nil
 }
 
-func (r *articleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int) ([]*entity.Article, error) {
+// GetArticlesByIDs fetches the articles with a single list request for ids
+// and restores the order of ids, which microCMS does not keep.
+func (r *articleRepository) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
+	if len(ids) == 0 {
+		return []*entity.Article{}, nil
+	}
+
+	// The order is restored by ID, so it is fetched even when not selected.
+	selected := convertFields(fields)
+	if id := apiFields[entity.ArticleFieldID]; len(selected) > 0 && !slices.Contains(selected, id) {
+		selected = append(selected, id)
+	}
+
 	var res articleListResponse
-	params := microcms.ListParams{
-		Endpoint: "blog",
-		Limit:    limit,
-		Offset:   offset,
-		Filters:  fmt.Sprintf("category[equals]%s", categorySlug),
+	params := ListParams{
+		Limit:  len(ids),
+		IDs:    ids,
+		Fields: selected,
 	}
 
-	err := r.microCMS.List(params, &res)
+	err := r.client.List(ctx, articlesEndpoint, params, &res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get articles by category: %w", err)
+		return nil, wrapError("failed to get articles by IDs", err)
 	}
 
-	articles := make([]*entity.Article, len(res.Contents))
-	for i, item := range res.Contents {
-		articles[i] = &entity.Article{
-			ID:    item.ID,
-			Title: item.Title,
-			Image: item.Image.URL,
-			Category: entity.Category{
-				Slug: item.Category.ID,
-				Name: item.Category.Name,
-			},
-			Description: item.Description,
-			Body:        item.Body,
-			PublishedAt: item.PublishedAt,
-			CreatedAt:   item.CreatedAt,
-			UpdatedAt:   item.UpdatedAt,
+	byID := make(map[string]*entity.Article, len(res.Contents))
+	for _, item := range res.Contents {
+		byID[item.ID] = convertToEntity(item)
+	}
+	articles := make([]*entity.Article, 0, len(ids))
+	for _, id := range ids {
+		if article, ok := byID[id]; ok {
+			articles = append(articles, article)
 		}
 	}
-
 	return articles, nil
 }
 
-func (r *articleRepository) GetPopularArticles(ctx context.Context, limit int) ([]*entity.Article, error) {
-	return r.GetArticles(ctx, limit, 0)
+func (r *articleRepository) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
+	var res article
+
+	err := r.client.Get(ctx, articlesEndpoint, id, GetParams{DraftKey: draftKey}, &res)
+	if err != nil {
+		return nil, wrapError("failed to get draft article by ID", err)
+	}
+
+	return convertToEntity(res), nil
 }
 
-func (r *articleRepository) GetLatestArticles(ctx context.Context, limit int) ([]*entity.Article, error) {
+func (r *articleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
 	var res articleListResponse
-	params := microcms.ListParams{
-		Endpoint: "blog",
-		Limit:    limit,
-		Offset:   0,
-		Orders:   []string{"-createdAt"},
+	params := ListParams{
+		Limit:   limit,
+		Offset:  offset,
+		Filters: fmt.Sprintf("category[equals]%s", categorySlug),
+		Fields:  convertFields(fields),
 	}
 
-	err := r.microCMS.List(params, &res)
+	err := r.client.List(ctx, articlesEndpoint, params, &res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest articles: %w", err)
+		return repository.ArticlePage{}, wrapError("failed to get articles by category", err)
 	}
 
-	articles := make([]*entity.Article, len(res.Contents))
-	for i, item := range res.Contents {
-		articles[i] = &entity.Article{
-			ID:    item.ID,
-			Title: item.Title,
-			Image: item.Image.URL,
-			Category: entity.Category{
-				Slug: item.Category.ID,
-				Name: item.Category.Name,
-			},
-			Description: item.Description,
-			Body:        item.Body,
-			PublishedAt: item.PublishedAt,
-			CreatedAt:   item.CreatedAt,
-			UpdatedAt:   item.UpdatedAt,
-		}
-	}
+	return convertToPage(res), nil
+}
 
-	return articles, nil
+// GetPopularArticles returns the latest articles, since microCMS knows
+// nothing of page views; popularity.NewArticleRepository ranks them instead.
+func (r *articleRepository) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	page, err := r.GetArticles(ctx, limit, 0, fields)
+	if err != nil {
+		return nil, err
+	}
+	return page.Articles, nil
 }
 
-func (r *articleRepository) CountArticles(ctx context.Context) (int, error) {
+func (r *articleRepository) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
 	var res articleListResponse
-	params := microcms.ListParams{
-		Endpoint: "blog",
-		Limit:    0,
+	params := ListParams{
+		Limit:  limit,
+		Orders: []string{"-createdAt"},
+		Fields: convertFields(fields),
 	}
 
-	err := r.microCMS.List(params, &res)
+	err := r.client.List(ctx, articlesEndpoint, params, &res)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count articles: %w", err)
+		return nil, wrapError("failed to get latest articles", err)
 	}
 
-	return res.TotalCount, nil
+	return convertToPage(res).Articles, nil
 }
 
-func (r *articleRepository) CountArticlesByCategory(ctx context.Context, categorySlug string) (int, error) {
-	var res articleListResponse
-	params := microcms.ListParams{
-		Endpoint: "blog",
-		Limit:    0,
-		Filters:  fmt.Sprintf("category[equals]%s", categorySlug),
+// convertToPage converts a list response, which already carries totalCount,
+// into a page so callers do not need a separate count request.
+func convertToPage(res articleListResponse) repository.ArticlePage {
+	articles := make([]*entity.Article, len(res.Contents))
+	for i, item := range res.Contents {
+		articles[i] = convertToEntity(item)
 	}
 
-	err := r.microCMS.List(params, &res)
-	if err != nil {
-		return 0, fmt.Errorf("failed to count articles by category: %w", err)
+	return repository.ArticlePage{
+		Articles: articles,
+		Total:    res.TotalCount,
+	}
+}
+
+func convertToEntity(item article) *entity.Article {
+	return &entity.Article{
+		ID:    item.ID,
+		Title: item.Title,
+		Image: item.Image.URL,
+		Category: entity.Category{
+			Slug: item.Category.ID,
+			Name: item.Category.Name,
+		},
+		Description: item.Description,
+		Body:        item.Body,
+		PublishedAt: item.PublishedAt,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+}
+
+// convertFields converts a field selection into the microCMS fields parameter.
+// An empty selection returns nil so that microCMS sends every field.
+func convertFields(fields []entity.ArticleField) []string {
+	if len(fields) == 0 {
+		return nil
 	}
 
-	return res.TotalCount, nil
+	result := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if name, ok := apiFields[f]; ok {
+			result = append(result, name)
+		}
+	}
+	return result
 }
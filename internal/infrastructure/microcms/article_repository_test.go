@@ -1,9 +1,16 @@
 package microcms_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
 	"github.com/kozennoki/nerine/internal/infrastructure/microcms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewArticleRepository(t *testing.T) {
@@ -12,9 +19,156 @@ func TestNewArticleRepository(t *testing.T) {
 	apiKey := "test-api-key"
 	serviceID := "test-service-id"
 
-	repo := microcms.NewArticleRepository(apiKey, serviceID)
+	repo := microcms.NewArticleRepository(microcms.NewClient(apiKey, serviceID))
 
 	if repo == nil {
 		t.Error("NewArticleRepository() returned nil")
 	}
 }
+
+func TestArticleRepository_GetArticles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blog", r.URL.Path)
+		assert.Equal(t, "id,title", r.URL.Query().Get("fields"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"contents": [{
+				"id": "a1",
+				"title": "Title",
+				"image": {"url": "https://example.com/a1.png"},
+				"category": {"id": "go", "name": "Go"},
+				"publishedAt": "2024-01-01T00:00:00Z"
+			}],
+			"totalCount": 21,
+			"offset": 0,
+			"limit": 10
+		}`))
+	}))
+	defer server.Close()
+
+	repo := microcms.NewArticleRepository(microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL)))
+
+	page, err := repo.GetArticles(context.Background(), 10, 0, []entity.ArticleField{entity.ArticleFieldID, entity.ArticleFieldTitle})
+
+	require.NoError(t, err)
+	assert.Equal(t, 21, page.Total)
+	require.Len(t, page.Articles, 1)
+	assert.Equal(t, "a1", page.Articles[0].ID)
+	assert.Equal(t, "https://example.com/a1.png", page.Articles[0].Image)
+	assert.Equal(t, entity.Category{Slug: "go", Name: "Go"}, page.Articles[0].Category)
+}
+
+func TestArticleRepository_GetArticlesByIDs(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		assert.Equal(t, "/blog", r.URL.Path)
+		assert.Equal(t, "c,a,deleted", query.Get("ids"))
+		assert.Equal(t, "3", query.Get("limit"))
+		assert.Equal(t, "title,id", query.Get("fields"))
+
+		// microCMS does not keep the order of ids.
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"contents":[{"id":"a","title":"A"},{"id":"c","title":"C"}],"totalCount":2}`))
+	}))
+	defer server.Close()
+
+	repo := microcms.NewArticleRepository(microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL)))
+
+	articles, err := repo.GetArticlesByIDs(context.Background(), []string{"c", "a", "deleted"}, []entity.ArticleField{entity.ArticleFieldTitle})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	require.Len(t, articles, 2)
+	assert.Equal(t, "c", articles[0].ID)
+	assert.Equal(t, "C", articles[0].Title)
+	assert.Equal(t, "a", articles[1].ID)
+}
+
+func TestArticleRepository_GetArticlesByIDs_Empty(t *testing.T) {
+	t.Parallel()
+
+	repo := microcms.NewArticleRepository(microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL("http://127.0.0.1:0")))
+
+	articles, err := repo.GetArticlesByIDs(context.Background(), nil, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, articles)
+}
+
+func TestArticleRepository_GetArticleByID_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Content is not found."}`))
+	}))
+	defer server.Close()
+
+	repo := microcms.NewArticleRepository(microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL)))
+
+	article, err := repo.GetArticleByID(context.Background(), "missing")
+
+	assert.Nil(t, article)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestArticleRepository_GetDraftArticleByID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blog/a1", r.URL.Path)
+		assert.Equal(t, "draft", r.URL.Query().Get("draftKey"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "a1", "title": "Draft", "category": {"id": "go", "name": "Go"}}`))
+	}))
+	defer server.Close()
+
+	repo := microcms.NewArticleRepository(microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL)))
+
+	article, err := repo.GetDraftArticleByID(context.Background(), "a1", "draft")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Draft", article.Title)
+}
+
+func TestConvertFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		fields []entity.ArticleField
+		want   []string
+	}{
+		{
+			name:   "empty selection requests every field",
+			fields: nil,
+			want:   nil,
+		},
+		{
+			name: "fields map to microCMS field IDs",
+			fields: []entity.ArticleField{
+				entity.ArticleFieldID,
+				entity.ArticleFieldTitle,
+				entity.ArticleFieldCategory,
+				entity.ArticleFieldPublishedAt,
+			},
+			want: []string{"id", "title", "category", "publishedAt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, microcms.ConvertFields(tt.fields))
+		})
+	}
+}
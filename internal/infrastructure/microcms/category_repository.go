@@ -2,21 +2,20 @@ package microcms
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
 	"github.com/kozennoki/nerine/internal/domain/repository"
-	"github.com/microcmsio/microcms-go-sdk"
 )
 
+const categoriesEndpoint = "categories"
+
 type categoryRepository struct {
-	microCMS *microcms.Client
+	client *Client
 }
 
-func NewCategoryRepository(apiKey, serviceID string) repository.CategoryRepository {
-	client := microcms.New(serviceID, apiKey)
+func NewCategoryRepository(client *Client) repository.CategoryRepository {
 	return &categoryRepository{
-		microCMS: client,
+		client: client,
 	}
 }
 
@@ -36,13 +35,9 @@ func (r *categoryRepository) GetCategories(
 	ctx context.Context,
 ) ([]*entity.Category, error) {
 	var res categoryListResponse
-	params := microcms.ListParams{
-		Endpoint: "categories",
-	}
-
-	err := r.microCMS.List(params, &res)
+	err := r.client.List(ctx, categoriesEndpoint, ListParams{}, &res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get categories: %w", err)
+		return nil, wrapError("failed to get categories", err)
 	}
 
 	categories := make([]*entity.Category, len(res.Contents))
@@ -59,13 +54,9 @@ func (r *categoryRepository) GetCategories(
 func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error) {
 	var res category
 
-	params := microcms.GetParams{
-		Endpoint:  "categories",
-		ContentID: slug,
-	}
-	err := r.microCMS.Get(params, &res)
+	err := r.client.Get(ctx, categoriesEndpoint, slug, GetParams{}, &res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get category by slug: %w", err)
+		return nil, wrapError("failed to get category by slug", err)
 	}
 
 	return &entity.Category{
@@ -12,7 +12,7 @@ func TestNewCategoryRepository(t *testing.T) {
 	apiKey := "test-api-key"
 	serviceID := "test-service-id"
 
-	repo := microcms.NewCategoryRepository(apiKey, serviceID)
+	repo := microcms.NewCategoryRepository(microcms.NewClient(apiKey, serviceID))
 
 	if repo == nil {
 		t.Error("NewCategoryRepository() returned nil")
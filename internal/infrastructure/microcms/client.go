@@ -1,16 +1,226 @@
 package microcms
 
 import (
-	"github.com/microcmsio/microcms-go-sdk"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/retry"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	apiKeyHeader   = "X-MICROCMS-API-KEY"
+	defaultTimeout = 10 * time.Second
+
+	// maxErrorBodySize bounds how much of an error response is kept.
+	maxErrorBodySize = 4 << 10
 )
 
+// sharedTransport is used by every client so that connections to microCMS
+// are pooled across repositories.
+var sharedTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   20,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   5 * time.Second,
+	ExpectContinueTimeout: time.Second,
+}
+
+// Client is a minimal microCMS content API client whose requests are bound
+// to the caller's context.
 type Client struct {
-	client *microcms.Client
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+type ClientOption func(*Client)
+
+// WithBaseURL overrides the API base URL, e.g. to point at a test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithTimeout sets the overall timeout of a single request.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetry retries requests that fail transiently according to policy.
+func WithRetry(policy retry.Policy) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = retry.NewTransport(c.httpClient.Transport, policy)
+	}
+}
+
+// WithTracing records a client span for every request sent to microCMS and
+// propagates the trace context. Draft keys are redacted from the spans. Put it
+// before WithRetry to get one span per attempt.
+func WithTracing() ClientOption {
+	return withTracing(otel.GetTracerProvider())
+}
+
+func withTracing(provider trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		transport := otelhttp.NewTransport(&restoringTransport{next: c.httpClient.Transport},
+			otelhttp.WithTracerProvider(provider),
+			otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+				return "microcms " + req.Method
+			}),
+		)
+		c.httpClient.Transport = &redactingTransport{next: transport}
+	}
+}
+
+func NewClient(apiKey, serviceID string, opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Transport: sharedTransport,
+			Timeout:   defaultTimeout,
+		},
+		baseURL: fmt.Sprintf("https://%s.microcms.io/api/v1", serviceID),
+		apiKey:  apiKey,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ListParams are the query parameters of a list request.
+type ListParams struct {
+	Limit    int
+	Offset   int
+	Orders   []string
+	Fields   []string
+	Filters  string
+	IDs      []string
+	DraftKey string
+}
+
+// GetParams are the query parameters of a single content request.
+type GetParams struct {
+	Fields   []string
+	DraftKey string
+}
+
+// HTTPError is returned when microCMS answers with a non-2xx status.
+type HTTPError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("microCMS API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// List fetches contents of endpoint and decodes the response into dst.
+func (c *Client) List(ctx context.Context, endpoint string, params ListParams, dst any) error {
+	query := url.Values{}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset != 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+	if len(params.Orders) > 0 {
+		query.Set("orders", strings.Join(params.Orders, ","))
+	}
+	if len(params.Fields) > 0 {
+		query.Set("fields", strings.Join(params.Fields, ","))
+	}
+	if params.Filters != "" {
+		query.Set("filters", params.Filters)
+	}
+	if len(params.IDs) > 0 {
+		query.Set("ids", strings.Join(params.IDs, ","))
+	}
+	if params.DraftKey != "" {
+		query.Set("draftKey", params.DraftKey)
+	}
+
+	return c.get(ctx, endpoint, query, dst)
+}
+
+// Get fetches a single content of endpoint and decodes the response into dst.
+func (c *Client) Get(ctx context.Context, endpoint, contentID string, params GetParams, dst any) error {
+	query := url.Values{}
+	if len(params.Fields) > 0 {
+		query.Set("fields", strings.Join(params.Fields, ","))
+	}
+	if params.DraftKey != "" {
+		query.Set("draftKey", params.DraftKey)
+	}
+
+	return c.get(ctx, endpoint+"/"+url.PathEscape(contentID), query, dst)
 }
 
-func NewClient(apiKey, serviceID string) *Client {
-	client := microcms.New(serviceID, apiKey)
-	return &Client{
-		client: client,
+func (c *Client) get(ctx context.Context, path string, query url.Values, dst any) error {
+	u := c.baseURL + "/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set(apiKeyHeader, c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", redactError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// newHTTPError builds an HTTPError from the {"message": ...} body microCMS
+// sends with errors, falling back to the raw body.
+func newHTTPError(resp *http.Response) *HTTPError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		message = payload.Message
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
 	}
 }
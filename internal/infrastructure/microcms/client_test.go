@@ -1,9 +1,21 @@
 package microcms_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/kozennoki/nerine/internal/domain/repository"
 	"github.com/kozennoki/nerine/internal/infrastructure/microcms"
+	"github.com/kozennoki/nerine/internal/infrastructure/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNewClient(t *testing.T) {
@@ -56,3 +68,244 @@ func TestNewClient_WithEmptyValues(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_List(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blog", r.URL.Path)
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MICROCMS-API-KEY"))
+
+		query := r.URL.Query()
+		assert.Equal(t, "5", query.Get("limit"))
+		assert.Equal(t, "10", query.Get("offset"))
+		assert.Equal(t, "-publishedAt", query.Get("orders"))
+		assert.Equal(t, "id,title", query.Get("fields"))
+		assert.Equal(t, "category[equals]go", query.Get("filters"))
+		assert.Equal(t, "a1,a2", query.Get("ids"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"contents":[{"id":"a1"}],"totalCount":11}`))
+	}))
+	defer server.Close()
+
+	client := microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL))
+
+	var res struct {
+		Contents []struct {
+			ID string `json:"id"`
+		} `json:"contents"`
+		TotalCount int `json:"totalCount"`
+	}
+	err := client.List(context.Background(), "blog", microcms.ListParams{
+		Limit:   5,
+		Offset:  10,
+		Orders:  []string{"-publishedAt"},
+		Fields:  []string{"id", "title"},
+		Filters: "category[equals]go",
+		IDs:     []string{"a1", "a2"},
+	}, &res)
+
+	require.NoError(t, err)
+	require.Len(t, res.Contents, 1)
+	assert.Equal(t, "a1", res.Contents[0].ID)
+	assert.Equal(t, 11, res.TotalCount)
+}
+
+func TestClient_Get(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/blog/a%2F1", r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"a/1"}`))
+	}))
+	defer server.Close()
+
+	client := microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL+"/"))
+
+	var res struct {
+		ID string `json:"id"`
+	}
+	err := client.Get(context.Background(), "blog", "a/1", microcms.GetParams{}, &res)
+
+	require.NoError(t, err)
+	assert.Equal(t, "a/1", res.ID)
+}
+
+func TestClient_HTTPError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+	}{
+		{
+			name:        "microCMS error message",
+			status:      http.StatusNotFound,
+			body:        `{"message":"Content is not found."}`,
+			wantMessage: "Content is not found.",
+		},
+		{
+			name:        "plain body",
+			status:      http.StatusBadGateway,
+			body:        "bad gateway",
+			wantMessage: "bad gateway",
+		},
+		{
+			name:        "empty body",
+			status:      http.StatusTooManyRequests,
+			body:        "",
+			wantMessage: "Too Many Requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL))
+
+			var res map[string]any
+			err := client.List(context.Background(), "blog", microcms.ListParams{}, &res)
+
+			var httpErr *microcms.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tt.status, httpErr.StatusCode)
+			assert.Equal(t, tt.wantMessage, httpErr.Message)
+		})
+	}
+}
+
+func TestClient_ContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := microcms.NewClient("test-api-key", "unused", microcms.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var res map[string]any
+	err := client.List(ctx, "blog", microcms.ListParams{}, &res)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline exceeded, got: %v", err)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := microcms.NewClient("test-api-key", "unused",
+		microcms.WithBaseURL(server.URL),
+		microcms.WithTimeout(50*time.Millisecond),
+	)
+
+	var res map[string]any
+	err := client.List(context.Background(), "blog", microcms.ListParams{}, &res)
+
+	assert.Error(t, err)
+}
+
+func TestClient_WithRetry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"Too many requests."}`))
+			return
+		}
+		assert.Equal(t, "test-api-key", r.Header.Get("X-MICROCMS-API-KEY"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"contents":[],"totalCount":0}`))
+	}))
+	defer server.Close()
+
+	client := microcms.NewClient("test-api-key", "unused",
+		microcms.WithBaseURL(server.URL),
+		microcms.WithRetry(retry.Policy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second}),
+	)
+
+	var res map[string]any
+	err := client.List(context.Background(), "blog", microcms.ListParams{}, &res)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_WithTracing_RedactsDraftKey(t *testing.T) {
+	t.Parallel()
+
+	const draftKey = "secret-draft-key"
+
+	var gotDraftKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDraftKey = r.URL.Query().Get("draftKey")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"a1"}`))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := microcms.NewClient("test-api-key", "unused",
+		microcms.WithBaseURL(server.URL),
+		microcms.WithTracingProvider(provider),
+	)
+
+	var res map[string]any
+	err := client.Get(context.Background(), "blog", "a1", microcms.GetParams{DraftKey: draftKey}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, draftKey, gotDraftKey, "microCMS must still receive the draft key")
+
+	// A refused connection fails in the transport, below http.Client.
+	server.Close()
+	err = client.Get(context.Background(), "blog", "a1", microcms.GetParams{DraftKey: draftKey}, &res)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), draftKey)
+	assert.ErrorIs(t, microcms.WrapError("failed", err), repository.ErrUpstreamUnavailable)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		for _, attr := range span.Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), draftKey, "attribute %s", attr.Key)
+		}
+		for _, event := range span.Events() {
+			for _, attr := range event.Attributes {
+				assert.NotContains(t, attr.Value.Emit(), draftKey, "event attribute %s", attr.Key)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package microcms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+// wrapError annotates err with msg and marks it with the repository error
+// matching the microCMS response or transport failure.
+func wrapError(msg string, err error) error {
+	if kind := classifyError(err); kind != nil {
+		return fmt.Errorf("%s: %w: %w", msg, kind, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+func classifyError(err error) error {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode == http.StatusNotFound:
+			return repository.ErrNotFound
+		case httpErr.StatusCode == http.StatusBadRequest:
+			return repository.ErrInvalidArgument
+		case httpErr.StatusCode == http.StatusTooManyRequests:
+			return repository.ErrRateLimited
+		case httpErr.StatusCode >= http.StatusInternalServerError:
+			return repository.ErrUpstreamUnavailable
+		}
+		return nil
+	}
+
+	// A canceled caller is not an upstream failure.
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return repository.ErrUpstreamUnavailable
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package microcms_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/microcms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "404 response is not found",
+			err:  &microcms.HTTPError{StatusCode: http.StatusNotFound, Message: "Content is not found."},
+			want: repository.ErrNotFound,
+		},
+		{
+			name: "400 response is invalid argument",
+			err:  &microcms.HTTPError{StatusCode: http.StatusBadRequest, Message: "Invalid query"},
+			want: repository.ErrInvalidArgument,
+		},
+		{
+			name: "429 response is rate limited",
+			err:  &microcms.HTTPError{StatusCode: http.StatusTooManyRequests, Message: "Too Many Requests"},
+			want: repository.ErrRateLimited,
+		},
+		{
+			name: "500 response is upstream unavailable",
+			err:  &microcms.HTTPError{StatusCode: http.StatusInternalServerError, Message: "Internal Server Error"},
+			want: repository.ErrUpstreamUnavailable,
+		},
+		{
+			name: "401 response is not classified",
+			err:  &microcms.HTTPError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"},
+		},
+		{
+			name: "transport error is upstream unavailable",
+			err:  &url.Error{Op: "Get", URL: "https://example.microcms.io", Err: errors.New("connection refused")},
+			want: repository.ErrUpstreamUnavailable,
+		},
+		{
+			name: "canceled request is not classified",
+			err:  &url.Error{Op: "Get", URL: "https://example.microcms.io", Err: context.Canceled},
+		},
+		{
+			name: "decode error is not classified",
+			err:  errors.New("failed to decode response"),
+		},
+	}
+
+	kinds := []error{
+		repository.ErrNotFound,
+		repository.ErrInvalidArgument,
+		repository.ErrRateLimited,
+		repository.ErrUpstreamUnavailable,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := microcms.WrapError("failed to get article by ID", tt.err)
+
+			assert.ErrorIs(t, got, tt.err)
+			assert.Contains(t, got.Error(), "failed to get article by ID")
+			for _, kind := range kinds {
+				assert.Equal(t, kind == tt.want, errors.Is(got, kind), "errors.Is(%v)", kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package microcms
+
+// Export private functions for testing
+var WrapError = wrapError
+var ConvertFields = convertFields
+var WithTracingProvider = withTracing
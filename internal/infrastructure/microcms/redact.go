@@ -0,0 +1,85 @@
+package microcms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// redactedValue replaces secret query parameters in traces and errors.
+const redactedValue = "REDACTED"
+
+// secretQueryParams grant access to content and must not leave the process
+// other than in the request to microCMS.
+var secretQueryParams = []string{"draftKey"}
+
+type rawQueryKey struct{}
+
+// redactURL returns u with the values of secret query parameters replaced.
+func redactURL(u *url.URL) *url.URL {
+	query := u.Query()
+	redacted := false
+	for _, param := range secretQueryParams {
+		if query.Has(param) {
+			query.Set(param, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u
+	}
+
+	clone := *u
+	clone.RawQuery = query.Encode()
+	return &clone
+}
+
+// redactError replaces the URL carried by a *url.Error, which http.Client
+// wraps around every transport failure, with its redacted form.
+func redactError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		urlErr.URL = redactURL(u).String()
+	} else {
+		urlErr.URL = ""
+	}
+	return err
+}
+
+// redactingTransport hands the transport it wraps a request whose secret
+// query parameters are redacted, so that the client span records no secret.
+// restoringTransport, placed below the span, puts the original query back.
+type redactingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *redactingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	redacted := redactURL(req.URL)
+	if redacted == req.URL {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := context.WithValue(req.Context(), rawQueryKey{}, req.URL.RawQuery)
+	clone := req.Clone(ctx)
+	clone.URL = redacted
+	return t.next.RoundTrip(clone)
+}
+
+type restoringTransport struct {
+	next http.RoundTripper
+}
+
+func (t *restoringTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rawQuery, ok := req.Context().Value(rawQueryKey{}).(string)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	clone := req.Clone(req.Context())
+	clone.URL.RawQuery = rawQuery
+	return t.next.RoundTrip(clone)
+}
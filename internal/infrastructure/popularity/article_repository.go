@@ -0,0 +1,95 @@
+package popularity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+// rankingOverfetch is how many times limit ranked IDs are looked up, so that
+// articles unpublished or deleted since they were viewed give their places to
+// the next most viewed ones rather than to the latest articles.
+const rankingOverfetch = 2
+
+type articleRepository struct {
+	next    repository.ArticleRepository
+	ranking repository.PopularityRepository
+}
+
+// NewArticleRepository ranks GetPopularArticles by the views recorded in
+// ranking, reading the ranked articles with a single GetArticlesByIDs call to
+// next. While fewer published articles have views than requested, the latest
+// articles fill the remaining places.
+func NewArticleRepository(
+	next repository.ArticleRepository,
+	ranking repository.PopularityRepository,
+) repository.ArticleRepository {
+	return &articleRepository{
+		next:    next,
+		ranking: ranking,
+	}
+}
+
+func (r *articleRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return r.next.GetArticles(ctx, limit, offset, fields)
+}
+
+func (r *articleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
+	return r.next.GetArticleByID(ctx, id)
+}
+
+func (r *articleRepository) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return r.next.GetArticlesByIDs(ctx, ids, fields)
+}
+
+func (r *articleRepository) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
+	return r.next.GetDraftArticleByID(ctx, id, draftKey)
+}
+
+func (r *articleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return r.next.GetArticlesByCategory(ctx, categorySlug, limit, offset, fields)
+}
+
+func (r *articleRepository) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	ids, err := r.ranking.RankArticleIDs(ctx, limit*rankingOverfetch)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]*entity.Article, 0, limit)
+	if len(ids) > 0 {
+		// Articles unpublished or deleted since they were viewed are left out.
+		ranked, err := r.next.GetArticlesByIDs(ctx, ids, fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get popular articles: %w", err)
+		}
+		articles = append(articles, ranked[:min(len(ranked), limit)]...)
+	}
+	if len(articles) == limit {
+		return articles, nil
+	}
+
+	seen := make(map[string]bool, len(articles))
+	for _, article := range articles {
+		seen[article.ID] = true
+	}
+	latest, err := r.next.GetLatestArticles(ctx, limit, fields)
+	if err != nil {
+		return nil, err
+	}
+	for _, article := range latest {
+		if len(articles) == limit {
+			break
+		}
+		if !seen[article.ID] {
+			articles = append(articles, article)
+		}
+	}
+	return articles, nil
+}
+
+func (r *articleRepository) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return r.next.GetLatestArticles(ctx, limit, fields)
+}
@@ -0,0 +1,116 @@
+package popularity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/popularity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func articleIDs(articles []*entity.Article) []string {
+	ids := make([]string, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func TestArticleRepository_GetPopularArticles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRanking := mocks.NewMockPopularityRepository(ctrl)
+
+	fields := []entity.ArticleField{entity.ArticleFieldID, entity.ArticleFieldTitle}
+	mockRanking.EXPECT().RankArticleIDs(gomock.Any(), 6).Return([]string{"b", "a", "c", "d"}, nil)
+	mockRepo.EXPECT().GetArticlesByIDs(gomock.Any(), []string{"b", "a", "c", "d"}, fields).
+		Return([]*entity.Article{{ID: "b"}, {ID: "a"}, {ID: "c"}, {ID: "d"}}, nil)
+
+	repo := popularity.NewArticleRepository(mockRepo, mockRanking)
+	articles, err := repo.GetPopularArticles(context.Background(), 3, fields)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, articleIDs(articles))
+}
+
+func TestArticleRepository_GetPopularArticles_SkipsMissingRanked(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRanking := mocks.NewMockPopularityRepository(ctrl)
+
+	fields := []entity.ArticleField{entity.ArticleFieldID}
+	mockRanking.EXPECT().RankArticleIDs(gomock.Any(), 4).Return([]string{"b", "deleted", "a", "c"}, nil)
+	mockRepo.EXPECT().GetArticlesByIDs(gomock.Any(), []string{"b", "deleted", "a", "c"}, fields).
+		Return([]*entity.Article{{ID: "b"}, {ID: "a"}, {ID: "c"}}, nil)
+
+	repo := popularity.NewArticleRepository(mockRepo, mockRanking)
+	articles, err := repo.GetPopularArticles(context.Background(), 2, fields)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, articleIDs(articles))
+}
+
+func TestArticleRepository_GetPopularArticles_FillsWithLatest(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRanking := mocks.NewMockPopularityRepository(ctrl)
+
+	fields := []entity.ArticleField{entity.ArticleFieldID}
+	mockRanking.EXPECT().RankArticleIDs(gomock.Any(), 6).Return([]string{"b", "deleted"}, nil)
+	mockRepo.EXPECT().GetArticlesByIDs(gomock.Any(), []string{"b", "deleted"}, fields).
+		Return([]*entity.Article{{ID: "b"}}, nil)
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 3, fields).
+		Return([]*entity.Article{{ID: "z"}, {ID: "b"}, {ID: "y"}}, nil)
+
+	repo := popularity.NewArticleRepository(mockRepo, mockRanking)
+	articles, err := repo.GetPopularArticles(context.Background(), 3, fields)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "z", "y"}, articleIDs(articles))
+}
+
+func TestArticleRepository_GetPopularArticles_UpstreamError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRanking := mocks.NewMockPopularityRepository(ctrl)
+
+	upstreamErr := errors.New("upstream error")
+	mockRanking.EXPECT().RankArticleIDs(gomock.Any(), 2).Return([]string{"a"}, nil)
+	mockRepo.EXPECT().GetArticlesByIDs(gomock.Any(), []string{"a"}, nil).Return(nil, upstreamErr)
+
+	repo := popularity.NewArticleRepository(mockRepo, mockRanking)
+	_, err := repo.GetPopularArticles(context.Background(), 1, nil)
+
+	assert.ErrorIs(t, err, upstreamErr)
+}
+
+func TestArticleRepository_GetPopularArticles_NoViews(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRanking := mocks.NewMockPopularityRepository(ctrl)
+
+	mockRanking.EXPECT().RankArticleIDs(gomock.Any(), 4).Return([]string{}, nil)
+	mockRepo.EXPECT().GetLatestArticles(gomock.Any(), 2, nil).
+		Return([]*entity.Article{{ID: "z"}, {ID: "y"}}, nil)
+
+	repo := popularity.NewArticleRepository(mockRepo, mockRanking)
+	articles, err := repo.GetPopularArticles(context.Background(), 2, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"z", "y"}, articleIDs(articles))
+}
@@ -0,0 +1,12 @@
+package popularity
+
+import "time"
+
+// Export private fields for testing
+func (t *Tracker) SetNow(now func() time.Time) {
+	t.now = now
+}
+
+func (s *MemoryStore) SetNow(now func() time.Time) {
+	s.now = now
+}
@@ -0,0 +1,215 @@
+package popularity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops the buckets older than
+// its retention and the expired visitors.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the views of a single process. Save and Load persist the
+// counts, but not the visitors, across restarts.
+type MemoryStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	// counts holds the views per article and bucket start in Unix seconds.
+	counts    map[string]map[int64]int64
+	visitors  map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore returns a store that forgets buckets older than retention.
+func NewMemoryStore(retention time.Duration) *MemoryStore {
+	return &MemoryStore{
+		retention: retention,
+		counts:    make(map[string]map[int64]int64),
+		visitors:  make(map[string]time.Time),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *MemoryStore) Record(_ context.Context, articleID, visitor string, at time.Time, dedup time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	key := articleID + "\x00" + visitor
+	if expiresAt, ok := s.visitors[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	if dedup > 0 {
+		s.visitors[key] = now.Add(dedup)
+	}
+
+	buckets, ok := s.counts[articleID]
+	if !ok {
+		buckets = make(map[int64]int64)
+		s.counts[articleID] = buckets
+	}
+	buckets[at.Truncate(BucketSize).Unix()]++
+	return true, nil
+}
+
+func (s *MemoryStore) Views(_ context.Context, since, until time.Time) ([]BucketViews, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var views []BucketViews
+	for id, buckets := range s.counts {
+		for start, n := range buckets {
+			bucket := time.Unix(start, 0).UTC()
+			if bucket.Before(since) || bucket.After(until) {
+				continue
+			}
+			views = append(views, BucketViews{ArticleID: id, Bucket: bucket, Views: n})
+		}
+	}
+	return views, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	oldest := now.Add(-s.retention).Truncate(BucketSize).Unix()
+	for id, buckets := range s.counts {
+		for start := range buckets {
+			if start < oldest {
+				delete(buckets, start)
+			}
+		}
+		if len(buckets) == 0 {
+			delete(s.counts, id)
+		}
+	}
+	for key, expiresAt := range s.visitors {
+		if !now.Before(expiresAt) {
+			delete(s.visitors, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// snapshot is the file format of Save and Load.
+type snapshot struct {
+	Views []snapshotViews `json:"views"`
+}
+
+type snapshotViews struct {
+	ArticleID string `json:"articleId"`
+	Bucket    int64  `json:"bucket"`
+	Views     int64  `json:"views"`
+}
+
+// Save writes the counts to path. The file is replaced atomically, so a
+// crash during Save leaves the previous snapshot in place.
+func (s *MemoryStore) Save(path string) error {
+	s.mu.Lock()
+	snap := snapshot{Views: []snapshotViews{}}
+	for id, buckets := range s.counts {
+		for start, n := range buckets {
+			snap.Views = append(snap.Views, snapshotViews{ArticleID: id, Bucket: start, Views: n})
+		}
+	}
+	s.mu.Unlock()
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode popularity snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create popularity snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write popularity snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write popularity snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace popularity snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load adds the counts saved in path to the store. A missing file is not an
+// error, so the first start begins with no views.
+func (s *MemoryStore) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read popularity snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode popularity snapshot: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range snap.Views {
+		buckets, ok := s.counts[v.ArticleID]
+		if !ok {
+			buckets = make(map[int64]int64)
+			s.counts[v.ArticleID] = buckets
+		}
+		buckets[v.Bucket] += v.Views
+	}
+	s.sweep(s.now())
+	return nil
+}
+
+// SaveEvery saves the counts to path every interval until the returned stop
+// function is called, which saves them a last time. Failed saves are passed
+// to onError.
+func (s *MemoryStore) SaveEvery(path string, interval time.Duration, onError func(error)) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Save(path); err != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			if err := s.Save(path); err != nil {
+				onError(err)
+			}
+		})
+	}
+}
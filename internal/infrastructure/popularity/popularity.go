@@ -0,0 +1,104 @@
+package popularity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// BucketSize is the resolution at which views are counted.
+const BucketSize = time.Hour
+
+// BucketViews is the number of views of an article in the bucket starting at
+// Bucket.
+type BucketViews struct {
+	ArticleID string
+	Bucket    time.Time
+	Views     int64
+}
+
+// Store counts views per article and bucket. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Record counts a view of articleID in the bucket containing at, unless
+	// visitor was already counted for it within dedup. It reports whether the
+	// view was counted.
+	Record(ctx context.Context, articleID, visitor string, at time.Time, dedup time.Duration) (bool, error)
+	// Views returns the counts of the buckets starting from since up to until.
+	Views(ctx context.Context, since, until time.Time) ([]BucketViews, error)
+}
+
+// Options configures a Tracker. Views older than Window are ignored, and a
+// view loses half its weight every HalfLife. A visitor is counted once per
+// article within Dedup.
+type Options struct {
+	Window   time.Duration
+	HalfLife time.Duration
+	Dedup    time.Duration
+}
+
+// Tracker records article views and ranks articles by their time-decayed
+// view count.
+type Tracker struct {
+	store Store
+	opts  Options
+	now   func() time.Time
+}
+
+func NewTracker(store Store, opts Options) *Tracker {
+	return &Tracker{
+		store: store,
+		opts:  opts,
+		now:   time.Now,
+	}
+}
+
+// RecordView counts a view of articleID by visitor, an opaque identifier such
+// as the client IP and User-Agent. The visitor is only kept hashed.
+func (t *Tracker) RecordView(ctx context.Context, articleID, visitor string) (bool, error) {
+	sum := sha256.Sum256([]byte(articleID + "\x00" + visitor))
+	counted, err := t.store.Record(ctx, articleID, hex.EncodeToString(sum[:16]), t.now(), t.opts.Dedup)
+	if err != nil {
+		return false, fmt.Errorf("failed to record article view: %w", err)
+	}
+	return counted, nil
+}
+
+// RankArticleIDs returns the IDs of at most limit articles viewed within the
+// window, most popular first. Each view weighs 2^(-age/HalfLife), its age
+// measured from the middle of its bucket.
+func (t *Tracker) RankArticleIDs(ctx context.Context, limit int) ([]string, error) {
+	now := t.now()
+	views, err := t.store.Views(ctx, now.Add(-t.opts.Window).Truncate(BucketSize), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read article views: %w", err)
+	}
+
+	scores := make(map[string]float64)
+	for _, v := range views {
+		age := now.Sub(v.Bucket.Add(BucketSize / 2))
+		if age < 0 {
+			age = 0
+		}
+		scores[v.ArticleID] += float64(v.Views) * math.Exp2(-age.Hours()/t.opts.HalfLife.Hours())
+	}
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
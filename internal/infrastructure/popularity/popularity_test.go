@@ -0,0 +1,201 @@
+package popularity_test
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/kozennoki/nerine/internal/infrastructure/popularity"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = popularity.Options{
+	Window:   7 * 24 * time.Hour,
+	HalfLife: 24 * time.Hour,
+	Dedup:    30 * time.Minute,
+}
+
+// clock is a manually advanced time source, kept in sync with miniredis so
+// that keys expire.
+type clock struct {
+	now   time.Time
+	redis *miniredis.Miniredis
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	c.redis.SetTime(c.now)
+	c.redis.FastForward(d)
+}
+
+func newTrackers(t *testing.T) map[string]struct {
+	tracker *popularity.Tracker
+	clock   *clock
+} {
+	t.Helper()
+
+	trackers := make(map[string]struct {
+		tracker *popularity.Tracker
+		clock   *clock
+	})
+	for _, name := range []string{"memory", "redis"} {
+		mr := miniredis.RunT(t)
+		c := &clock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), redis: mr}
+		mr.SetTime(c.now)
+
+		var store popularity.Store
+		if name == "memory" {
+			memory := popularity.NewMemoryStore(testOptions.Window)
+			memory.SetNow(c.Now)
+			store = memory
+		} else {
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { client.Close() })
+			store = popularity.NewRedisStore(client, testOptions.Window)
+		}
+
+		tracker := popularity.NewTracker(store, testOptions)
+		tracker.SetNow(c.Now)
+		trackers[name] = struct {
+			tracker *popularity.Tracker
+			clock   *clock
+		}{tracker, c}
+	}
+	return trackers
+}
+
+func TestTracker_DeduplicatesVisitors(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range newTrackers(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			counted, err := tt.tracker.RecordView(ctx, "a", "visitor-1")
+			require.NoError(t, err)
+			assert.True(t, counted)
+
+			counted, err = tt.tracker.RecordView(ctx, "a", "visitor-1")
+			require.NoError(t, err)
+			assert.False(t, counted, "same visitor within the dedup window")
+
+			counted, err = tt.tracker.RecordView(ctx, "b", "visitor-1")
+			require.NoError(t, err)
+			assert.True(t, counted, "same visitor, another article")
+
+			tt.clock.Advance(testOptions.Dedup)
+			counted, err = tt.tracker.RecordView(ctx, "a", "visitor-1")
+			require.NoError(t, err)
+			assert.True(t, counted, "same visitor after the dedup window")
+		})
+	}
+}
+
+func TestTracker_RankArticleIDs(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range newTrackers(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			view := func(id string, visitors int) {
+				for i := range visitors {
+					_, err := tt.tracker.RecordView(ctx, id, string(rune('a'+i)))
+					require.NoError(t, err)
+				}
+			}
+
+			// "old" was viewed most, but long enough ago to decay below the
+			// recent articles; "expired" falls out of the window.
+			view("expired", 20)
+			tt.clock.Advance(3 * 24 * time.Hour)
+			view("old", 8)
+			tt.clock.Advance(5 * 24 * time.Hour)
+			view("recent", 3)
+			view("recent-less", 2)
+
+			ids, err := tt.tracker.RankArticleIDs(ctx, 10)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"recent", "recent-less", "old"}, ids)
+
+			ids, err = tt.tracker.RankArticleIDs(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"recent"}, ids)
+		})
+	}
+}
+
+func TestRedisStore_Record_FailureLeavesVisitorUnseen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	store := popularity.NewRedisStore(client, testOptions.Window)
+
+	// A bucket key of the wrong type makes the count fail.
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mr.SetTime(at)
+	bucketKey := "nerine:popularity:views:" + strconv.FormatInt(at.Unix(), 10)
+	require.NoError(t, mr.Set(bucketKey, "broken"))
+
+	counted, err := store.Record(ctx, "a", "visitor-1", at, testOptions.Dedup)
+	require.Error(t, err)
+	assert.False(t, counted)
+
+	mr.Del(bucketKey)
+	counted, err = store.Record(ctx, "a", "visitor-1", at, testOptions.Dedup)
+	require.NoError(t, err)
+	assert.True(t, counted, "a retry after a failed count is counted")
+
+	views, err := store.Views(ctx, at, at)
+	require.NoError(t, err)
+	assert.Equal(t, []popularity.BucketViews{{ArticleID: "a", Bucket: at, Views: 1}}, views)
+}
+
+func TestMemoryStore_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "popularity.json")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store := popularity.NewMemoryStore(testOptions.Window)
+	store.SetNow(func() time.Time { return now })
+	stop := store.SaveEvery(path, time.Hour, func(err error) { t.Errorf("Save failed: %v", err) })
+	_, err := store.Record(ctx, "a", "visitor-1", now, time.Minute)
+	require.NoError(t, err)
+	_, err = store.Record(ctx, "a", "visitor-2", now, time.Minute)
+	require.NoError(t, err)
+	stop()
+
+	restored := popularity.NewMemoryStore(testOptions.Window)
+	restored.SetNow(func() time.Time { return now })
+	require.NoError(t, restored.Load(path))
+
+	views, err := restored.Views(ctx, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.Equal(t, []popularity.BucketViews{{ArticleID: "a", Bucket: now.Truncate(time.Hour), Views: 2}}, views)
+
+	// Views older than the retention are dropped on load.
+	later := popularity.NewMemoryStore(testOptions.Window)
+	later.SetNow(func() time.Time { return now.Add(testOptions.Window + 2*time.Hour) })
+	require.NoError(t, later.Load(path))
+	views, err = later.Views(ctx, time.Time{}, now.Add(testOptions.Window+2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, views)
+}
+
+func TestMemoryStore_Load_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	store := popularity.NewMemoryStore(testOptions.Window)
+	assert.NoError(t, store.Load(filepath.Join(t.TempDir(), "missing.json")))
+}
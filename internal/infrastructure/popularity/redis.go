@@ -0,0 +1,98 @@
+package popularity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisKeyPrefix namespaces the counts in a shared Redis. Each bucket is
+	// a hash of article ID to views under "<prefix>views:<bucket start>".
+	redisKeyPrefix = "nerine:popularity:"
+	redisViewsKey  = redisKeyPrefix + "views:"
+	redisSeenKey   = redisKeyPrefix + "seen:"
+)
+
+// RedisStore keeps the views in Redis, so that replicas share the counts and
+// the deduplication of visitors.
+type RedisStore struct {
+	client    redis.Cmdable
+	retention time.Duration
+}
+
+// NewRedisStore returns a store whose buckets expire after retention.
+func NewRedisStore(client redis.Cmdable, retention time.Duration) *RedisStore {
+	return &RedisStore{
+		client:    client,
+		retention: retention,
+	}
+}
+
+// recordScript counts a view unless the visitor was seen within the dedup
+// window (ARGV[2] milliseconds, 0 to count every view). Scripts run
+// atomically, and nothing is written before the count succeeds, so a failed
+// attempt never leaves the visitor marked as seen.
+var recordScript = redis.NewScript(`
+if ARGV[2] ~= "0" and redis.call("EXISTS", KEYS[1]) == 1 then
+	return 0
+end
+redis.call("HINCRBY", KEYS[2], ARGV[1], 1)
+redis.call("EXPIREAT", KEYS[2], ARGV[3])
+if ARGV[2] ~= "0" then
+	redis.call("SET", KEYS[1], 1, "PX", ARGV[2])
+end
+return 1
+`)
+
+func (s *RedisStore) Record(ctx context.Context, articleID, visitor string, at time.Time, dedup time.Duration) (bool, error) {
+	bucket := at.Truncate(BucketSize)
+	keys := []string{
+		redisSeenKey + articleID + ":" + visitor,
+		redisViewsKey + strconv.FormatInt(bucket.Unix(), 10),
+	}
+	counted, err := recordScript.Run(ctx, s.client, keys,
+		articleID,
+		max(dedup.Milliseconds(), 0),
+		bucket.Add(BucketSize+s.retention).Unix(),
+	).Bool()
+	if err != nil {
+		return false, fmt.Errorf("failed to count view: %w", err)
+	}
+	return counted, nil
+}
+
+func (s *RedisStore) Views(ctx context.Context, since, until time.Time) ([]BucketViews, error) {
+	var (
+		buckets []time.Time
+		cmds    []*redis.MapStringStringCmd
+	)
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for bucket := since.Truncate(BucketSize); !bucket.After(until); bucket = bucket.Add(BucketSize) {
+			if bucket.Before(since) {
+				continue
+			}
+			buckets = append(buckets, bucket)
+			cmds = append(cmds, pipe.HGetAll(ctx, redisViewsKey+strconv.FormatInt(bucket.Unix(), 10)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read views: %w", err)
+	}
+
+	var views []BucketViews
+	for i, cmd := range cmds {
+		for id, value := range cmd.Val() {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			views = append(views, BucketViews{ArticleID: id, Bucket: buckets[i], Views: n})
+		}
+	}
+	return views, nil
+}
@@ -0,0 +1,8 @@
+package preview
+
+import "time"
+
+// Export private fields for testing
+func (s *Sealer) SetNow(now func() time.Time) {
+	s.now = now
+}
@@ -0,0 +1,133 @@
+package preview
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errors returned by Sealer.Open.
+var (
+	ErrInvalidToken = errors.New("invalid preview token")
+	ErrExpiredToken = errors.New("expired preview token")
+)
+
+// Token grants read access to the draft of one article until ExpiresAt.
+type Token struct {
+	ArticleID string
+	DraftKey  string
+	ExpiresAt time.Time
+}
+
+// payload is the encrypted part of a token.
+type payload struct {
+	ArticleID string `json:"id"`
+	DraftKey  string `json:"dk"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// keyInfo separates the token key from other keys derived from the secret.
+const keyInfo = "nerine preview token"
+
+// Sealer seals and opens preview tokens. A token is the base64url nonce
+// and AES-256-GCM sealed JSON payload, under a key derived from the secret
+// with HKDF-SHA256. The payload is encrypted, so a token holder cannot read
+// the draft key, and authenticated, so it cannot be altered. Rotating the
+// secret revokes every token issued before.
+type Sealer struct {
+	key []byte
+	now func() time.Time
+}
+
+func NewSealer(secret string) *Sealer {
+	// HKDF only fails for keys longer than 255 hash sizes.
+	key, _ := hkdf.Key(sha256.New, []byte(secret), nil, keyInfo, 32)
+	return &Sealer{
+		key: key,
+		now: time.Now,
+	}
+}
+
+// Seal returns an encrypted token for the draft of articleID that expires
+// after ttl.
+func (s *Sealer) Seal(articleID, draftKey string, ttl time.Duration) (string, Token, error) {
+	token := Token{
+		ArticleID: articleID,
+		DraftKey:  draftKey,
+		ExpiresAt: s.now().Add(ttl).Truncate(time.Second),
+	}
+	body, err := json.Marshal(payload{
+		ArticleID: token.ArticleID,
+		DraftKey:  token.DraftKey,
+		ExpiresAt: token.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", Token{}, fmt.Errorf("failed to encode preview token: %w", err)
+	}
+
+	aead, err := s.aead()
+	if err != nil {
+		return "", Token{}, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(body)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", Token{}, fmt.Errorf("failed to generate preview token nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, body, nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), token, nil
+}
+
+// Open decrypts token, checking that it was issued with the same secret and
+// has not expired, and returns its contents.
+func (s *Sealer) Open(token string) (Token, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Token{}, ErrInvalidToken
+	}
+	aead, err := s.aead()
+	if err != nil {
+		return Token{}, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return Token{}, ErrInvalidToken
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	body, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return Token{}, ErrInvalidToken
+	}
+
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil || p.ArticleID == "" || p.DraftKey == "" {
+		return Token{}, ErrInvalidToken
+	}
+
+	t := Token{
+		ArticleID: p.ArticleID,
+		DraftKey:  p.DraftKey,
+		ExpiresAt: time.Unix(p.ExpiresAt, 0),
+	}
+	if !s.now().Before(t.ExpiresAt) {
+		return Token{}, ErrExpiredToken
+	}
+	return t, nil
+}
+
+func (s *Sealer) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview token cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview token cipher: %w", err)
+	}
+	return aead, nil
+}
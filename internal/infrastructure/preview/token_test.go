@@ -0,0 +1,109 @@
+package preview_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/preview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestSealer_SealAndOpen(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sealer := preview.NewSealer(testSecret)
+	sealer.SetNow(func() time.Time { return now })
+
+	token, issued, err := sealer.Seal("article-1", "draft-key", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), issued.ExpiresAt)
+
+	got, err := sealer.Open(token)
+	require.NoError(t, err)
+	assert.Equal(t, "article-1", got.ArticleID)
+	assert.Equal(t, "draft-key", got.DraftKey)
+	assert.True(t, got.ExpiresAt.Equal(issued.ExpiresAt))
+}
+
+func TestSealer_Open_Expired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sealer := preview.NewSealer(testSecret)
+	sealer.SetNow(func() time.Time { return now })
+
+	token, _, err := sealer.Seal("article-1", "draft-key", time.Hour)
+	require.NoError(t, err)
+
+	sealer.SetNow(func() time.Time { return now.Add(time.Hour - time.Second) })
+	_, err = sealer.Open(token)
+	assert.NoError(t, err)
+
+	sealer.SetNow(func() time.Time { return now.Add(time.Hour) })
+	_, err = sealer.Open(token)
+	assert.ErrorIs(t, err, preview.ErrExpiredToken)
+}
+
+func TestSealer_Seal_HidesDraftKey(t *testing.T) {
+	t.Parallel()
+
+	const draftKey = "secret-draft-key"
+	sealer := preview.NewSealer(testSecret)
+
+	first, _, err := sealer.Seal("article-1", draftKey, time.Hour)
+	require.NoError(t, err)
+	second, _, err := sealer.Seal("article-1", draftKey, time.Hour)
+	require.NoError(t, err)
+
+	for _, token := range []string{first, second} {
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), draftKey)
+		assert.NotContains(t, string(raw), "article-1")
+	}
+	assert.NotEqual(t, first, second, "expected a fresh nonce for every token")
+}
+
+func TestSealer_Open_Invalid(t *testing.T) {
+	t.Parallel()
+
+	sealer := preview.NewSealer(testSecret)
+	token, _, err := sealer.Seal("article-1", "draft-key", time.Hour)
+	require.NoError(t, err)
+
+	rotated, _, err := preview.NewSealer(strings.Repeat("x", 32)).Seal("article-1", "draft-key", time.Hour)
+	require.NoError(t, err)
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	flipped := bytes.Clone(raw)
+	flipped[len(flipped)/2] ^= 1
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "empty", token: ""},
+		{name: "not base64url", token: "!" + token},
+		{name: "shorter than a nonce", token: base64.RawURLEncoding.EncodeToString(raw[:8])},
+		{name: "truncated", token: base64.RawURLEncoding.EncodeToString(raw[:len(raw)-1])},
+		{name: "altered", token: base64.RawURLEncoding.EncodeToString(flipped)},
+		{name: "sealed with another secret", token: rotated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := sealer.Open(tt.token)
+			assert.ErrorIs(t, err, preview.ErrInvalidToken)
+		})
+	}
+}
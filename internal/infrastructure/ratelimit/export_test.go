@@ -0,0 +1,12 @@
+package ratelimit
+
+import "time"
+
+// Export private fields for testing
+func (s *MemoryStore) SetNow(now func() time.Time) {
+	s.now = now
+}
+
+func (s *RedisStore) SetNow(now func() time.Time) {
+	s.now = now
+}
@@ -0,0 +1,125 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/kozennoki/nerine/internal/infrastructure/ratelimit"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a manually advanced time source.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newStores(t *testing.T, c *clock) map[string]ratelimit.Store {
+	t.Helper()
+
+	memory := ratelimit.NewMemoryStore()
+	memory.SetNow(c.Now)
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	shared := ratelimit.NewRedisStore(client)
+	shared.SetNow(c.Now)
+
+	return map[string]ratelimit.Store{"memory": memory, "redis": shared}
+}
+
+func TestStore_TokenBucket(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limit := ratelimit.Limit{Requests: 3, Period: 3 * time.Second}
+
+	for name, store := range newStores(t, c) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			key := "key:web:" + name
+
+			for i, wantRemaining := range []int{2, 1, 0} {
+				result, err := store.Take(ctx, key, limit)
+				require.NoError(t, err)
+				assert.True(t, result.Allowed, "request %d", i)
+				assert.Equal(t, 3, result.Limit)
+				assert.Equal(t, wantRemaining, result.Remaining)
+			}
+
+			result, err := store.Take(ctx, key, limit)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+			assert.Equal(t, time.Second, result.RetryAfter)
+			assert.Equal(t, 3*time.Second, result.Reset)
+
+			// Other keys have buckets of their own
+			result, err = store.Take(ctx, "ip:192.0.2.1:"+name, limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			// One token per second comes back
+			c.Advance(1500 * time.Millisecond)
+			result, err = store.Take(ctx, key, limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+			assert.Equal(t, 2500*time.Millisecond, result.Reset)
+
+			// The bucket never holds more than its size
+			c.Advance(time.Hour)
+			result, err = store.Take(ctx, key, limit)
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.Remaining)
+		})
+	}
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{now: time.Now()}
+	store := ratelimit.NewMemoryStore()
+	store.SetNow(c.Now)
+	ctx := context.Background()
+
+	_, err := store.Take(ctx, "short", ratelimit.Limit{Requests: 10, Period: time.Second})
+	require.NoError(t, err)
+	_, err = store.Take(ctx, "long", ratelimit.Limit{Requests: 10, Period: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.Len())
+
+	// The next take after the sweep interval drops the refilled bucket only
+	c.Advance(2 * time.Minute)
+	_, err = store.Take(ctx, "other", ratelimit.Limit{Requests: 10, Period: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestRedisStore_Error(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	server.Close()
+
+	_, err := ratelimit.NewRedisStore(client).Take(context.Background(), "key:web", ratelimit.Limit{Requests: 1, Period: time.Second})
+	assert.ErrorContains(t, err, "failed to take rate limit token")
+}
+
+func TestLimit_Unlimited(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ratelimit.Limit{}.Unlimited())
+	assert.False(t, ratelimit.Limit{Requests: 1, Period: time.Second}.Unlimited())
+}
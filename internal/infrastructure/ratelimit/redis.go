@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces the buckets in a shared Redis.
+const redisKeyPrefix = "nerine:ratelimit:"
+
+// takeScript refills and takes from a bucket atomically. The bucket expires
+// once it would be full again, since a missing bucket is a full one.
+//
+// KEYS[1]: bucket, ARGV: size, tokens per millisecond, now in milliseconds.
+// Returns whether the take was allowed and the tokens left, as a string
+// because Redis truncates Lua numbers to integers.
+var takeScript = redis.NewScript(`
+local size = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = size
+	ts = now
+end
+if now > ts then
+	tokens = math.min(size, tokens + (now - ts) * rate)
+	ts = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil((size - tokens) / rate) + 1)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps the buckets in Redis, so that replicas share them.
+type RedisStore struct {
+	client redis.Scripter
+	now    func() time.Time
+}
+
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	perMillisecond := limit.perSecond() / 1000
+	reply, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		limit.Requests, perMillisecond, s.now().UnixMilli(),
+	).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("failed to take rate limit token: unexpected reply %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	tokensText, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensText, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse rate limit tokens: %w", err)
+	}
+	return newResult(limit, tokens, allowed == 1), nil
+}
@@ -0,0 +1,3 @@
+package retry
+
+var ParseRetryAfter = parseRetryAfter
@@ -0,0 +1,156 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// maxDrainSize bounds how much of a discarded response is read so that its
+// connection can be reused.
+const maxDrainSize = 4 << 10
+
+// Policy controls how often and how long a failed request is retried.
+// A MaxAttempts of 1 or less disables retries.
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Transport retries idempotent requests (GET and HEAD) that fail with a
+// transport error or a 429, 502, 503 or 504 response. Attempts are spaced by
+// exponential backoff with full jitter, or by the Retry-After header when
+// the upstream sends one. A retry is only made if it can start before the
+// request's context deadline; otherwise the last response is returned.
+type Transport struct {
+	base   http.RoundTripper
+	policy Policy
+}
+
+func NewTransport(base http.RoundTripper, policy Policy) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:   base,
+		policy: policy,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !retryable(req) || t.policy.MaxAttempts <= 1 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.MaxAttempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay, ok := t.delay(attempt, resp)
+		if !ok || !fitsDeadline(ctx, delay) {
+			return resp, err
+		}
+		logRetry(ctx, req, attempt, delay, resp, err)
+		if resp != nil {
+			_, _ = io.CopyN(io.Discard, resp.Body, maxDrainSize)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// logRetry logs with the logger of the request being served, if any.
+func logRetry(ctx context.Context, req *http.Request, attempt int, delay time.Duration, resp *http.Response, err error) {
+	fields := []zap.Field{
+		zap.String("host", req.URL.Host),
+		zap.Int("attempt", attempt),
+		zap.Duration("delay", delay),
+	}
+	if resp != nil {
+		fields = append(fields, zap.Int("upstream_status", resp.StatusCode))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	logger.FromContext(ctx).Info("Retrying upstream request", fields...)
+}
+
+// retryable reports whether req can safely be sent again.
+func retryable(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt. It reports false
+// when the upstream asks for a longer wait than MaxDelay.
+func (t *Transport) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d, d <= t.policy.MaxDelay
+		}
+	}
+
+	backoff := t.policy.MaxDelay
+	if shift := attempt - 1; shift < 32 {
+		if d := t.policy.BaseDelay << shift; d > 0 && d < backoff {
+			backoff = d
+		}
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return rand.N(backoff + 1), true
+}
+
+// parseRetryAfter accepts both the delay-seconds and the HTTP-date form.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func fitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > delay
+}
@@ -0,0 +1,209 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = retry.Policy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+// newFlakyServer answers with statuses in order, then 200 for every
+// further request.
+func newFlakyServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n <= len(statuses) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newClient(policy retry.Policy) *http.Client {
+	return &http.Client{Transport: retry.NewTransport(http.DefaultTransport, policy)}
+}
+
+func TestTransport_RetriesTransientStatuses(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		server, calls := newFlakyServer(t, nil, status, status)
+
+		resp, err := newClient(testPolicy).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "status %d", status)
+		assert.Equal(t, int32(3), calls.Load(), "status %d", status)
+	}
+}
+
+func TestTransport_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	server, calls := newFlakyServer(t, nil, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+	resp, err := newClient(testPolicy).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestTransport_DoesNotRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		method string
+		status int
+	}{
+		{name: "non-idempotent request", method: http.MethodPost, status: http.StatusServiceUnavailable},
+		{name: "not found", method: http.MethodGet, status: http.StatusNotFound},
+		{name: "internal server error", method: http.MethodGet, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, calls := newFlakyServer(t, nil, tt.status)
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(""))
+			require.NoError(t, err)
+			resp, err := newClient(testPolicy).Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, int32(1), calls.Load())
+		})
+	}
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("is honored", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newFlakyServer(t, http.Header{"Retry-After": {"0"}}, http.StatusTooManyRequests)
+
+		resp, err := newClient(retry.Policy{MaxAttempts: 2, BaseDelay: time.Hour, MaxDelay: time.Hour}).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("longer than max delay is not waited for", func(t *testing.T) {
+		t.Parallel()
+
+		server, calls := newFlakyServer(t, http.Header{"Retry-After": {"120"}}, http.StatusTooManyRequests)
+
+		resp, err := newClient(testPolicy).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestTransport_StopsAtDeadline(t *testing.T) {
+	t.Parallel()
+
+	server, calls := newFlakyServer(t, nil, http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := newClient(retry.Policy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransport_RetriesTransportErrors(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newFlakyServer(t, nil)
+
+	var calls atomic.Int32
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := &http.Client{Transport: retry.NewTransport(base, testPolicy)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", wantOK: false},
+		{value: "5", want: 5 * time.Second, wantOK: true},
+		{value: "-1", wantOK: false},
+		{value: "Fri, 01 Mar 2024 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{value: "Fri, 01 Mar 2024 11:00:00 GMT", want: 0, wantOK: true},
+		{value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := retry.ParseRetryAfter(tt.value, now)
+		assert.Equal(t, tt.wantOK, ok, "value %q", tt.value)
+		assert.Equal(t, tt.want, got, "value %q", tt.value)
+	}
+}
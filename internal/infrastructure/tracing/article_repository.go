@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type articleRepository struct {
+	next   repository.ArticleRepository
+	tracer trace.Tracer
+	source string
+}
+
+// NewArticleRepository runs every call to next in a span named
+// "<source>.<method>".
+func NewArticleRepository(
+	next repository.ArticleRepository,
+	tracer trace.Tracer,
+	source string,
+) repository.ArticleRepository {
+	return &articleRepository{
+		next:   next,
+		tracer: tracer,
+		source: source,
+	}
+}
+
+func (r *articleRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return call(ctx, r.tracer, r.source+".GetArticles", func(ctx context.Context) (repository.ArticlePage, error) {
+		return r.next.GetArticles(ctx, limit, offset, fields)
+	}, trace.WithAttributes(limitAttr(limit), offsetAttr(offset)))
+}
+
+func (r *articleRepository) GetArticleByID(ctx context.Context, id string) (*entity.Article, error) {
+	return call(ctx, r.tracer, r.source+".GetArticleByID", func(ctx context.Context) (*entity.Article, error) {
+		return r.next.GetArticleByID(ctx, id)
+	}, trace.WithAttributes(attribute.String("nerine.article.id", id)))
+}
+
+func (r *articleRepository) GetArticlesByIDs(ctx context.Context, ids []string, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return call(ctx, r.tracer, r.source+".GetArticlesByIDs", func(ctx context.Context) ([]*entity.Article, error) {
+		return r.next.GetArticlesByIDs(ctx, ids, fields)
+	}, trace.WithAttributes(attribute.StringSlice("nerine.article.ids", ids)))
+}
+
+// GetDraftArticleByID leaves the draft key out of the span; it grants access
+// to unpublished content.
+func (r *articleRepository) GetDraftArticleByID(ctx context.Context, id, draftKey string) (*entity.Article, error) {
+	return call(ctx, r.tracer, r.source+".GetDraftArticleByID", func(ctx context.Context) (*entity.Article, error) {
+		return r.next.GetDraftArticleByID(ctx, id, draftKey)
+	}, trace.WithAttributes(attribute.String("nerine.article.id", id)))
+}
+
+func (r *articleRepository) GetArticlesByCategory(ctx context.Context, categorySlug string, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return call(ctx, r.tracer, r.source+".GetArticlesByCategory", func(ctx context.Context) (repository.ArticlePage, error) {
+		return r.next.GetArticlesByCategory(ctx, categorySlug, limit, offset, fields)
+	}, trace.WithAttributes(categoryAttr(categorySlug), limitAttr(limit), offsetAttr(offset)))
+}
+
+func (r *articleRepository) GetPopularArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return call(ctx, r.tracer, r.source+".GetPopularArticles", func(ctx context.Context) ([]*entity.Article, error) {
+		return r.next.GetPopularArticles(ctx, limit, fields)
+	}, trace.WithAttributes(limitAttr(limit)))
+}
+
+func (r *articleRepository) GetLatestArticles(ctx context.Context, limit int, fields []entity.ArticleField) ([]*entity.Article, error) {
+	return call(ctx, r.tracer, r.source+".GetLatestArticles", func(ctx context.Context) ([]*entity.Article, error) {
+		return r.next.GetLatestArticles(ctx, limit, fields)
+	}, trace.WithAttributes(limitAttr(limit)))
+}
+
+func limitAttr(limit int) attribute.KeyValue {
+	return attribute.Int("nerine.limit", limit)
+}
+
+func offsetAttr(offset int) attribute.KeyValue {
+	return attribute.Int("nerine.offset", offset)
+}
+
+func categoryAttr(slug string) attribute.KeyValue {
+	return attribute.String("nerine.category.slug", slug)
+}
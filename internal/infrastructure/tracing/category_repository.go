@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type categoryRepository struct {
+	next   repository.CategoryRepository
+	tracer trace.Tracer
+	source string
+}
+
+// NewCategoryRepository runs every call to next in a span named
+// "<source>.<method>".
+func NewCategoryRepository(
+	next repository.CategoryRepository,
+	tracer trace.Tracer,
+	source string,
+) repository.CategoryRepository {
+	return &categoryRepository{
+		next:   next,
+		tracer: tracer,
+		source: source,
+	}
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context) ([]*entity.Category, error) {
+	return call(ctx, r.tracer, r.source+".GetCategories", func(ctx context.Context) ([]*entity.Category, error) {
+		return r.next.GetCategories(ctx)
+	})
+}
+
+func (r *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	return call(ctx, r.tracer, r.source+".GetCategoryBySlug", func(ctx context.Context) (*entity.Category, error) {
+		return r.next.GetCategoryBySlug(ctx, slug)
+	}, trace.WithAttributes(categoryAttr(slug)))
+}
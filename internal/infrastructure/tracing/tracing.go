@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// ScopeName is the instrumentation scope of the spans created by nerine.
+const ScopeName = "github.com/kozennoki/nerine"
+
+// Exporter selects where spans are sent.
+type Exporter string
+
+const (
+	ExporterNone   Exporter = "none"
+	ExporterStdout Exporter = "stdout"
+	// ExporterOTLP sends spans over OTLP/HTTP. The endpoint and headers are
+	// read from the standard OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLP Exporter = "otlp"
+)
+
+type Config struct {
+	Exporter    Exporter
+	ServiceName string
+	// SampleRatio is the share of new traces that are recorded. Requests
+	// that carry a sampled traceparent are always recorded.
+	SampleRatio float64
+}
+
+// Provider owns the tracer provider installed by Setup.
+type Provider struct {
+	tracerProvider trace.TracerProvider
+	shutdown       func(context.Context) error
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. With ExporterNone spans are not recorded, but an incoming
+// traceparent is still passed on to the upstreams.
+func Setup(ctx context.Context, cfg Config) (*Provider, error) {
+	if cfg.Exporter == ExporterNone || cfg.Exporter == "" {
+		tp := noop.NewTracerProvider()
+		install(tp)
+		return &Provider{
+			tracerProvider: tp,
+			shutdown:       func(context.Context) error { return nil },
+		}, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg.Exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	install(tp)
+	return &Provider{
+		tracerProvider: tp,
+		shutdown:       tp.Shutdown,
+	}, nil
+}
+
+func install(tp trace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+func newExporter(ctx context.Context, exporter Exporter) (sdktrace.SpanExporter, error) {
+	switch exporter {
+	case ExporterStdout:
+		e, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		return e, nil
+	case ExporterOTLP:
+		e, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		return e, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+}
+
+// Tracer returns the tracer used by the decorators of this package.
+func (p *Provider) Tracer() trace.Tracer {
+	return p.tracerProvider.Tracer(ScopeName)
+}
+
+// Shutdown flushes the spans that have not been exported yet.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.shutdown(ctx)
+}
+
+// call runs fn in a span named name and records the error it returns.
+func call[T any](ctx context.Context, tracer trace.Tracer, name string, fn func(context.Context) (T, error), opts ...trace.SpanStartOption) (T, error) {
+	ctx, span := tracer.Start(ctx, name, opts...)
+	defer span.End()
+
+	v, err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return v, err
+}
@@ -0,0 +1,106 @@
+package tracing_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/tracing"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func newRecordingTracer() (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return tp.Tracer(tracing.ScopeName), recorder
+}
+
+func TestNewUsecase_NestsRepositorySpans(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRepo.EXPECT().GetArticleByID(gomock.Any(), "article-1").Return(&entity.Article{ID: "article-1"}, nil)
+
+	repo := tracing.NewArticleRepository(mockRepo, tracer, "microcms")
+	uc := tracing.NewUsecase[usecase.GetArticleByIDUsecaseInput, usecase.GetArticleByIDUsecaseOutput](
+		usecase.NewGetArticleByID(repo), tracer, "GetArticleByID")
+
+	_, err := uc.Exec(context.Background(), usecase.GetArticleByIDUsecaseInput{ID: "article-1"})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	repoSpan, usecaseSpan := spans[0], spans[1]
+	assert.Equal(t, "microcms.GetArticleByID", repoSpan.Name())
+	assert.Equal(t, "usecase.GetArticleByID", usecaseSpan.Name())
+	assert.Equal(t, usecaseSpan.SpanContext().SpanID(), repoSpan.Parent().SpanID())
+	assert.Contains(t, repoSpan.Attributes(), attribute.String("nerine.article.id", "article-1"))
+	assert.Equal(t, codes.Unset, repoSpan.Status().Code)
+}
+
+func TestNewZennRepository_RecordsErrors(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer()
+	upstreamErr := fmt.Errorf("zenn API returned status 502: %w", repository.ErrUpstreamUnavailable)
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, nil).Return(repository.ArticlePage{}, upstreamErr)
+
+	repo := tracing.NewZennRepository(mockRepo, tracer, "zenn")
+	_, err := repo.GetArticles(context.Background(), 10, 0, nil)
+	assert.ErrorIs(t, err, repository.ErrUpstreamUnavailable)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "zenn.GetArticles", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, upstreamErr.Error(), spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+// TestSetup_PropagatesTraceContext changes the global tracer provider and
+// propagator, so it does not run in parallel.
+func TestSetup_PropagatesTraceContext(t *testing.T) {
+	provider, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone})
+	require.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	incoming := http.Header{}
+	incoming.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(incoming))
+
+	ctx, span := provider.Tracer().Start(ctx, "request")
+	defer span.End()
+
+	outgoing := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(outgoing))
+	assert.Contains(t, outgoing.Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	t.Parallel()
+
+	_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "jaeger"})
+	assert.Error(t, err)
+}
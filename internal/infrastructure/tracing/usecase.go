@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Usecase is the shape shared by every usecase interface.
+type Usecase[I, O any] interface {
+	Exec(ctx context.Context, input I) (O, error)
+}
+
+type usecase[I, O any] struct {
+	next   Usecase[I, O]
+	tracer trace.Tracer
+	name   string
+}
+
+// NewUsecase runs every Exec of next in a span named "usecase.<name>".
+func NewUsecase[I, O any](
+	next Usecase[I, O],
+	tracer trace.Tracer,
+	name string,
+) Usecase[I, O] {
+	return &usecase[I, O]{
+		next:   next,
+		tracer: tracer,
+		name:   "usecase." + name,
+	}
+}
+
+func (u *usecase[I, O]) Exec(ctx context.Context, input I) (O, error) {
+	return call(ctx, u.tracer, u.name, func(ctx context.Context) (O, error) {
+		return u.next.Exec(ctx, input)
+	})
+}
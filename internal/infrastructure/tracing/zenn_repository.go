@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type zennRepository struct {
+	next   repository.ArticleReader
+	tracer trace.Tracer
+	source string
+}
+
+// NewZennRepository runs every call to the Zenn article source in a span
+// named "<source>.<method>".
+func NewZennRepository(
+	next repository.ArticleReader,
+	tracer trace.Tracer,
+	source string,
+) repository.ArticleReader {
+	return &zennRepository{
+		next:   next,
+		tracer: tracer,
+		source: source,
+	}
+}
+
+func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int, fields []entity.ArticleField) (repository.ArticlePage, error) {
+	return call(ctx, r.tracer, r.source+".GetArticles", func(ctx context.Context) (repository.ArticlePage, error) {
+		return r.next.GetArticles(ctx, limit, offset, fields)
+	}, trace.WithAttributes(limitAttr(limit), offsetAttr(offset)))
+}
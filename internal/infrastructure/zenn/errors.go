@@ -0,0 +1,39 @@
+package zenn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+// statusError describes a non-200 Zenn response, marked with the matching
+// repository error when there is one.
+func statusError(status int) error {
+	var kind error
+	switch {
+	case status == http.StatusNotFound:
+		kind = repository.ErrNotFound
+	case status == http.StatusBadRequest:
+		kind = repository.ErrInvalidArgument
+	case status == http.StatusTooManyRequests:
+		kind = repository.ErrRateLimited
+	case status >= http.StatusInternalServerError:
+		kind = repository.ErrUpstreamUnavailable
+	}
+	if kind == nil {
+		return fmt.Errorf("zenn API returned status %d", status)
+	}
+	return fmt.Errorf("zenn API returned status %d: %w", status, kind)
+}
+
+// wrapTransportError marks a failed request as ErrUpstreamUnavailable unless
+// the caller gave up on it.
+func wrapTransportError(msg string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return fmt.Errorf("%s: %w: %w", msg, repository.ErrUpstreamUnavailable, err)
+}
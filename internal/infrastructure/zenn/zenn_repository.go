@@ -10,6 +10,8 @@ import (
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
 	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/retry"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 const (
@@ -22,22 +24,43 @@ type zennRepository struct {
 	baseURL    string
 }
 
-func NewZennRepository() repository.ArticleReader {
-	return &zennRepository{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		baseURL: baseURL,
+type Option func(*zennRepository)
+
+// WithRetry retries requests that fail transiently according to policy.
+func WithRetry(policy retry.Policy) Option {
+	return func(r *zennRepository) {
+		r.httpClient.Transport = retry.NewTransport(r.httpClient.Transport, policy)
 	}
 }
 
-func NewZennRepositoryWithBaseURL(baseURL string) repository.ArticleReader {
-	return &zennRepository{
+// WithTracing records a client span for every request sent to Zenn and
+// propagates the trace context. Put it before WithRetry to get one span per
+// attempt.
+func WithTracing() Option {
+	return func(r *zennRepository) {
+		r.httpClient.Transport = otelhttp.NewTransport(r.httpClient.Transport,
+			otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+				return "zenn " + req.Method
+			}),
+		)
+	}
+}
+
+func NewZennRepository(opts ...Option) repository.ArticleReader {
+	return NewZennRepositoryWithBaseURL(baseURL, opts...)
+}
+
+func NewZennRepositoryWithBaseURL(baseURL string, opts ...Option) repository.ArticleReader {
+	r := &zennRepository{
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
 		baseURL: baseURL,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 type zennArticle struct {
@@ -69,9 +92,10 @@ type zennAPIResponse struct {
 	TotalCount *int          `json:"total_count"`
 }
 
-func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int) ([]*entity.Article, error) {
+// GetArticles ignores fields because the Zenn API has no field selection.
+func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int, _ []entity.ArticleField) (repository.ArticlePage, error) {
 	if limit == 0 {
-		return nil, fmt.Errorf("limit must be greater than 0")
+		return repository.ArticlePage{}, fmt.Errorf("limit must be greater than 0: %w", repository.ErrInvalidArgument)
 	}
 	page := (offset / limit) + 1
 
@@ -79,22 +103,22 @@ func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int) ([]
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return repository.ArticlePage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch articles from Zenn: %w", err)
+		return repository.ArticlePage{}, wrapTransportError("failed to fetch articles from Zenn", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("zenn API returned status %d", resp.StatusCode)
+		return repository.ArticlePage{}, statusError(resp.StatusCode)
 	}
 
 	var zennResp zennAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&zennResp); err != nil {
-		return nil, fmt.Errorf("failed to decode Zenn response: %w", err)
+		return repository.ArticlePage{}, fmt.Errorf("failed to decode Zenn response: %w", err)
 	}
 
 	articles := make([]*entity.Article, 0, len(zennResp.Articles))
@@ -103,7 +127,15 @@ func (r *zennRepository) GetArticles(ctx context.Context, limit, offset int) ([]
 		articles = append(articles, article)
 	}
 
-	return articles, nil
+	total := 0
+	if zennResp.TotalCount != nil {
+		total = *zennResp.TotalCount
+	}
+
+	return repository.ArticlePage{
+		Articles: articles,
+		Total:    total,
+	}, nil
 }
 
 func convertToEntity(zennArticle zennArticle) *entity.Article {
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/retry"
 	"github.com/kozennoki/nerine/internal/infrastructure/zenn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,12 +62,13 @@ func TestZennRepository_GetArticles_Success(t *testing.T) {
 
 	repo := zenn.NewZennRepositoryWithBaseURL(server.URL)
 
-	articles, err := repo.GetArticles(context.Background(), 10, 0)
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
 
 	require.NoError(t, err)
-	require.Len(t, articles, 1)
+	require.Len(t, page.Articles, 1)
+	assert.Equal(t, 0, page.Total)
 
-	article := articles[0]
+	article := page.Articles[0]
 	assert.Equal(t, "test-article", article.ID)
 	assert.Equal(t, "📝Test Article", article.Title)
 	assert.Equal(t, "zenn", article.Category.Slug)
@@ -76,6 +80,30 @@ func TestZennRepository_GetArticles_Success(t *testing.T) {
 	assert.Equal(t, updatedAt.UTC(), article.UpdatedAt)
 }
 
+func TestZennRepository_GetArticles_TotalCount(t *testing.T) {
+	t.Parallel()
+
+	mockResponse := map[string]interface{}{
+		"articles":    []map[string]interface{}{},
+		"next_page":   2,
+		"total_count": 42,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	repo := zenn.NewZennRepositoryWithBaseURL(server.URL)
+
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, page.Articles)
+	assert.Equal(t, 42, page.Total)
+}
+
 func TestZennRepository_GetArticles_PaginationCalculation(t *testing.T) {
 	t.Parallel()
 
@@ -125,7 +153,7 @@ func TestZennRepository_GetArticles_PaginationCalculation(t *testing.T) {
 			defer server.Close()
 
 			repo := zenn.NewZennRepositoryWithBaseURL(server.URL)
-			_, err := repo.GetArticles(context.Background(), tc.limit, tc.offset)
+			_, err := repo.GetArticles(context.Background(), tc.limit, tc.offset, nil)
 
 			assert.NoError(t, err)
 		})
@@ -143,11 +171,70 @@ func TestZennRepository_GetArticles_HTTPError(t *testing.T) {
 
 	repo := zenn.NewZennRepositoryWithBaseURL(server.URL)
 
-	articles, err := repo.GetArticles(context.Background(), 10, 0)
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
 
 	assert.Error(t, err)
-	assert.Nil(t, articles)
+	assert.Nil(t, page.Articles)
 	assert.Contains(t, err.Error(), "zenn API returned status 500")
+	assert.ErrorIs(t, err, repository.ErrUpstreamUnavailable)
+}
+
+func TestZennRepository_GetArticles_StatusErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "not found", status: http.StatusNotFound, want: repository.ErrNotFound},
+		{name: "bad request", status: http.StatusBadRequest, want: repository.ErrInvalidArgument},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: repository.ErrRateLimited},
+		{name: "bad gateway", status: http.StatusBadGateway, want: repository.ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			repo := zenn.NewZennRepositoryWithBaseURL(server.URL)
+
+			_, err := repo.GetArticles(context.Background(), 10, 0, nil)
+
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestZennRepository_GetArticles_RetriesBadGateway(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"articles":[{"id":1,"slug":"retried","title":"Retried"}],"total_count":1}`))
+	}))
+	defer server.Close()
+
+	repo := zenn.NewZennRepositoryWithBaseURL(server.URL,
+		zenn.WithRetry(retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	)
+
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
+
+	require.NoError(t, err)
+	require.Len(t, page.Articles, 1)
+	assert.Equal(t, "retried", page.Articles[0].ID)
+	assert.Equal(t, int32(2), calls.Load())
 }
 
 func TestZennRepository_GetArticles_InvalidJSON(t *testing.T) {
@@ -161,10 +248,10 @@ func TestZennRepository_GetArticles_InvalidJSON(t *testing.T) {
 
 	repo := zenn.NewZennRepositoryWithBaseURL(server.URL)
 
-	articles, err := repo.GetArticles(context.Background(), 10, 0)
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
 
 	assert.Error(t, err)
-	assert.Nil(t, articles)
+	assert.Nil(t, page.Articles)
 	assert.Contains(t, err.Error(), "failed to decode Zenn response")
 }
 
@@ -182,10 +269,10 @@ func TestZennRepository_GetArticles_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 	defer cancel()
 
-	articles, err := repo.GetArticles(ctx, 10, 0)
+	page, err := repo.GetArticles(ctx, 10, 0, nil)
 
 	assert.Error(t, err)
-	assert.Nil(t, articles)
+	assert.Nil(t, page.Articles)
 	assert.Contains(t, err.Error(), "failed to fetch articles from Zenn")
 }
 
@@ -195,11 +282,12 @@ func TestZennRepository_GetArticles_ZeroLimit(t *testing.T) {
 	repo := zenn.NewZennRepositoryWithBaseURL("http://example.com")
 
 	// This should handle the zero limit case
-	articles, err := repo.GetArticles(context.Background(), 0, 0)
+	page, err := repo.GetArticles(context.Background(), 0, 0, nil)
 
 	assert.Error(t, err)
-	assert.Nil(t, articles)
+	assert.Nil(t, page.Articles)
 	assert.Contains(t, err.Error(), "limit must be greater than 0")
+	assert.ErrorIs(t, err, repository.ErrInvalidArgument)
 }
 
 func TestZennRepository_GetArticles_InvalidURL(t *testing.T) {
@@ -208,9 +296,9 @@ func TestZennRepository_GetArticles_InvalidURL(t *testing.T) {
 	// Use an invalid URL that would cause http.NewRequestWithContext to fail
 	repo := zenn.NewZennRepositoryWithBaseURL("ht\ttp://invalid-url")
 
-	articles, err := repo.GetArticles(context.Background(), 10, 0)
+	page, err := repo.GetArticles(context.Background(), 10, 0, nil)
 
 	assert.Error(t, err)
-	assert.Nil(t, articles)
+	assert.Nil(t, page.Articles)
 	assert.Contains(t, err.Error(), "failed to create request")
 }
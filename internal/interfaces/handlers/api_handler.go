@@ -13,6 +13,8 @@ type APIHandler struct {
 	getArticlesByCategoryUsecase usecase.GetArticlesByCategoryUsecase
 	getCategoriesUsecase         usecase.GetCategoriesUsecase
 	getZennArticlesUsecase       usecase.GetZennArticlesUsecase
+	getHealthUsecase             usecase.GetHealthUsecase
+	errorRenderer                *ErrorRenderer
 }
 
 func NewAPIHandler(
@@ -23,6 +25,8 @@ func NewAPIHandler(
 	getArticlesByCategoryUsecase usecase.GetArticlesByCategoryUsecase,
 	getCategoriesUsecase usecase.GetCategoriesUsecase,
 	getZennArticlesUsecase usecase.GetZennArticlesUsecase,
+	getHealthUsecase usecase.GetHealthUsecase,
+	errorRenderer *ErrorRenderer,
 ) *APIHandler {
 	return &APIHandler{
 		getArticlesUsecase:           getArticlesUsecase,
@@ -32,6 +36,8 @@ func NewAPIHandler(
 		getArticlesByCategoryUsecase: getArticlesByCategoryUsecase,
 		getCategoriesUsecase:         getCategoriesUsecase,
 		getZennArticlesUsecase:       getZennArticlesUsecase,
+		getHealthUsecase:             getHealthUsecase,
+		errorRenderer:                errorRenderer,
 	}
 }
 
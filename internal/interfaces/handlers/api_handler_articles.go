@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/kozennoki/nerine/internal/domain/entity"
 	"github.com/kozennoki/nerine/internal/interfaces/presenter"
 	"github.com/kozennoki/nerine/internal/openapi"
 	"github.com/kozennoki/nerine/internal/usecase"
 	"github.com/labstack/echo/v4"
 )
 
+// DraftKeyParam is the query parameter carrying the microCMS draft key of an
+// article preview.
+const DraftKeyParam = "draftKey"
+
 func (h *APIHandler) GetArticles(ctx echo.Context, params openapi.GetArticlesParams) error {
 	page := 1
 	if params.Page != nil {
@@ -20,46 +27,48 @@ func (h *APIHandler) GetArticles(ctx echo.Context, params openapi.GetArticlesPar
 		limit = *params.Limit
 	}
 
+	fields, err := parseFields(ctx)
+	if err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid fields parameter", presenter.InvalidParam{Name: "fields", Reason: err.Error()})
+	}
+
 	input := usecase.GetArticlesUsecaseInput{
-		Page:  page,
-		Limit: limit,
+		Page:   page,
+		Limit:  limit,
+		Fields: fields,
 	}
 
 	output, err := h.getArticlesUsecase.Exec(ctx.Request().Context(), input)
 	if err != nil {
-		ctx.Logger().Error("Failed to get articles: ", err)
-		errorMsg := presenter.ConvertErrorMessage(err)
-		return ctx.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get articles",
-			Detail: &errorMsg,
-		})
+		return h.errorRenderer.Render(ctx, "Failed to get articles", err)
 	}
 
-	return ctx.JSON(http.StatusOK, openapi.ArticlesResponse{
-		Articles:   presenter.ConvertArticles(output.Articles),
+	return ctx.JSON(http.StatusOK, presenter.PartialArticlesResponse{
+		Articles:   presenter.ConvertPartialArticles(output.Articles, output.Fields),
 		Pagination: presenter.ConvertPagination(output.Pagination),
 	})
 }
 
 func (h *APIHandler) GetArticleById(ctx echo.Context, id string) error {
+	draftKey := ctx.QueryParam(DraftKeyParam)
+	if draftKey != "" {
+		// Drafts must not outlive the preview in any shared or browser cache,
+		// and neither must the errors of a preview.
+		ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	}
+
 	if id == "" {
-		return ctx.JSON(http.StatusBadRequest, openapi.ErrorResponse{
-			Error: "Article ID is required",
-		})
+		return h.errorRenderer.RenderBadRequest(ctx, "Article ID is required", presenter.InvalidParam{Name: "id", Reason: "is required"})
 	}
 
 	input := usecase.GetArticleByIDUsecaseInput{
-		ID: id,
+		ID:       id,
+		DraftKey: draftKey,
 	}
 
 	output, err := h.getArticleByIDUsecase.Exec(ctx.Request().Context(), input)
 	if err != nil {
-		ctx.Logger().Error("Failed to get article by ID: ", err)
-		errorMsg := presenter.ConvertErrorMessage(err)
-		return ctx.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get article",
-			Detail: &errorMsg,
-		})
+		return h.errorRenderer.Render(ctx, "Failed to get article", err)
 	}
 
 	return ctx.JSON(http.StatusOK, openapi.ArticleResponse{
@@ -73,22 +82,23 @@ func (h *APIHandler) GetPopularArticles(ctx echo.Context, params openapi.GetPopu
 		limit = *params.Limit
 	}
 
+	fields, err := parseFields(ctx)
+	if err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid fields parameter", presenter.InvalidParam{Name: "fields", Reason: err.Error()})
+	}
+
 	input := usecase.GetPopularArticlesUsecaseInput{
-		Limit: limit,
+		Limit:  limit,
+		Fields: fields,
 	}
 
 	output, err := h.getPopularArticlesUsecase.Exec(ctx.Request().Context(), input)
 	if err != nil {
-		ctx.Logger().Error("Failed to get popular articles: ", err)
-		errorMsg := presenter.ConvertErrorMessage(err)
-		return ctx.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get articles",
-			Detail: &errorMsg,
-		})
+		return h.errorRenderer.Render(ctx, "Failed to get articles", err)
 	}
 
-	return ctx.JSON(http.StatusOK, openapi.ArticlesResponse{
-		Articles: presenter.ConvertArticles(output.Articles),
+	return ctx.JSON(http.StatusOK, presenter.PartialArticlesResponse{
+		Articles: presenter.ConvertPartialArticles(output.Articles, output.Fields),
 	})
 }
 
@@ -98,30 +108,29 @@ func (h *APIHandler) GetLatestArticles(ctx echo.Context, params openapi.GetLates
 		limit = *params.Limit
 	}
 
+	fields, err := parseFields(ctx)
+	if err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid fields parameter", presenter.InvalidParam{Name: "fields", Reason: err.Error()})
+	}
+
 	input := usecase.GetLatestArticlesUsecaseInput{
-		Limit: limit,
+		Limit:  limit,
+		Fields: fields,
 	}
 
 	output, err := h.getLatestArticlesUsecase.Exec(ctx.Request().Context(), input)
 	if err != nil {
-		ctx.Logger().Error("Failed to get latest articles: ", err)
-		errorMsg := presenter.ConvertErrorMessage(err)
-		return ctx.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get articles",
-			Detail: &errorMsg,
-		})
+		return h.errorRenderer.Render(ctx, "Failed to get articles", err)
 	}
 
-	return ctx.JSON(http.StatusOK, openapi.ArticlesResponse{
-		Articles: presenter.ConvertArticles(output.Articles),
+	return ctx.JSON(http.StatusOK, presenter.PartialArticlesResponse{
+		Articles: presenter.ConvertPartialArticles(output.Articles, output.Fields),
 	})
 }
 
 func (h *APIHandler) GetArticlesByCategory(ctx echo.Context, slug string, params openapi.GetArticlesByCategoryParams) error {
 	if slug == "" {
-		return ctx.JSON(http.StatusBadRequest, openapi.ErrorResponse{
-			Error: "Category slug is required",
-		})
+		return h.errorRenderer.RenderBadRequest(ctx, "Category slug is required", presenter.InvalidParam{Name: "slug", Reason: "is required"})
 	}
 
 	page := 1
@@ -134,24 +143,45 @@ func (h *APIHandler) GetArticlesByCategory(ctx echo.Context, slug string, params
 		limit = *params.Limit
 	}
 
+	fields, err := parseFields(ctx)
+	if err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid fields parameter", presenter.InvalidParam{Name: "fields", Reason: err.Error()})
+	}
+
 	input := usecase.GetArticlesByCategoryUsecaseInput{
 		CategorySlug: slug,
 		Page:         page,
 		Limit:        limit,
+		Fields:       fields,
 	}
 
 	output, err := h.getArticlesByCategoryUsecase.Exec(ctx.Request().Context(), input)
 	if err != nil {
-		ctx.Logger().Error("Failed to get articles by category: ", err)
-		errorMsg := presenter.ConvertErrorMessage(err)
-		return ctx.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get articles",
-			Detail: &errorMsg,
-		})
+		return h.errorRenderer.Render(ctx, "Failed to get articles", err)
 	}
 
-	return ctx.JSON(http.StatusOK, openapi.ArticlesResponse{
-		Articles:   presenter.ConvertArticles(output.Articles),
+	return ctx.JSON(http.StatusOK, presenter.PartialArticlesResponse{
+		Articles:   presenter.ConvertPartialArticles(output.Articles, output.Fields),
 		Pagination: presenter.ConvertPagination(output.Pagination),
 	})
 }
+
+// parseFields reads the fields query parameter, a comma-separated list of
+// article field names that may also be repeated. It returns nil when absent.
+func parseFields(ctx echo.Context) ([]entity.ArticleField, error) {
+	var fields []entity.ArticleField
+	for _, value := range ctx.QueryParams()["fields"] {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			field, ok := entity.ParseArticleField(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown field %q", name)
+			}
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
@@ -1,14 +1,19 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
 	"github.com/kozennoki/nerine/internal/infrastructure/utils"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
 	"github.com/kozennoki/nerine/internal/openapi"
 	"github.com/kozennoki/nerine/internal/usecase"
 	"github.com/labstack/echo/v4"
@@ -138,6 +143,34 @@ func TestAPIHandler_GetArticleById(t *testing.T) {
 			mockError:      errors.New("not found"),
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:           "Article not found",
+			id:             "missing-id",
+			mockOutput:     usecase.GetArticleByIDUsecaseOutput{},
+			mockError:      fmt.Errorf("failed to get article by ID: %w", repository.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Upstream rate limited",
+			id:             "test-id",
+			mockOutput:     usecase.GetArticleByIDUsecaseOutput{},
+			mockError:      fmt.Errorf("failed to get article by ID: %w", repository.ErrRateLimited),
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "Upstream unavailable",
+			id:             "test-id",
+			mockOutput:     usecase.GetArticleByIDUsecaseOutput{},
+			mockError:      fmt.Errorf("failed to get article by ID: %w", repository.ErrUpstreamUnavailable),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Invalid argument",
+			id:             "test-id",
+			mockOutput:     usecase.GetArticleByIDUsecaseOutput{},
+			mockError:      fmt.Errorf("failed to get article by ID: %w", repository.ErrInvalidArgument),
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -147,7 +180,7 @@ func TestAPIHandler_GetArticleById(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			if tt.id != "" && tt.expectedStatus != http.StatusBadRequest {
+			if tt.id != "" {
 				mocks.GetArticleByIDUsecase.EXPECT().
 					Exec(gomock.Any(), usecase.GetArticleByIDUsecaseInput{ID: tt.id}).
 					Return(tt.mockOutput, tt.mockError)
@@ -161,6 +194,78 @@ func TestAPIHandler_GetArticleById(t *testing.T) {
 			if rec.Code != tt.expectedStatus {
 				t.Errorf("GetArticleById() status = %v, want %v", rec.Code, tt.expectedStatus)
 			}
+			if tt.mockError != nil {
+				var response presenter.ErrorResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if response.Error != "Failed to get article" {
+					t.Errorf("GetArticleById() error = %q, want %q", response.Error, "Failed to get article")
+				}
+				if response.Code != presenter.ConvertErrorCode(tt.expectedStatus) {
+					t.Errorf("GetArticleById() code = %q, want %q", response.Code, presenter.ConvertErrorCode(tt.expectedStatus))
+				}
+				if response.Detail != nil {
+					t.Errorf("GetArticleById() detail = %q, want none", *response.Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIHandler_GetArticleById_Draft(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockOutput     usecase.GetArticleByIDUsecaseOutput
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			mockOutput:     usecase.GetArticleByIDUsecaseOutput{Article: &entity.Article{ID: "test-id", Title: "Draft"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown draft",
+			mockError:      fmt.Errorf("failed to get article by ID: %w", repository.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Upstream unavailable",
+			mockError:      fmt.Errorf("failed to get article by ID: %w", repository.ErrUpstreamUnavailable),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			handler, mocks := CreateTestAPIHandler(ctrl)
+
+			mocks.GetArticleByIDUsecase.EXPECT().
+				Exec(gomock.Any(), usecase.GetArticleByIDUsecaseInput{ID: "test-id", DraftKey: "draft"}).
+				Return(tt.mockOutput, tt.mockError)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles/test-id?draftKey=draft", nil)
+			rec := httptest.NewRecorder()
+
+			err := handler.GetArticleById(e.NewContext(req, rec), "test-id")
+
+			if err != nil {
+				t.Errorf("GetArticleById() error = %v", err)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("GetArticleById() status = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+			// Errors for a draft must not be cached either.
+			if got := rec.Header().Get(echo.HeaderCacheControl); got != "no-store" {
+				t.Errorf("GetArticleById() Cache-Control = %q, want %q", got, "no-store")
+			}
 		})
 	}
 }
@@ -402,3 +507,64 @@ func TestAPIHandler_GetArticlesByCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIHandler_GetArticles_Fields(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mocks := CreateTestAPIHandler(ctrl)
+
+	selected := []entity.ArticleField{entity.ArticleFieldTitle, entity.ArticleFieldImage}
+	mocks.GetArticlesUsecase.EXPECT().
+		Exec(gomock.Any(), usecase.GetArticlesUsecaseInput{Page: 1, Limit: 10, Fields: selected}).
+		Return(usecase.GetArticlesUsecaseOutput{
+			Articles: []*entity.Article{{ID: "1", Title: "Test Article", Image: "test.jpg", Body: "<p>body</p>"}},
+			Fields:   []entity.ArticleField{entity.ArticleFieldID, entity.ArticleFieldTitle, entity.ArticleFieldImage},
+		}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?fields=title,Image", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := handler.GetArticles(c, openapi.GetArticlesParams{}); err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GetArticles() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var body struct {
+		Articles []map[string]any `json:"articles"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := map[string]any{"ID": "1", "Title": "Test Article", "Image": "test.jpg"}
+	if len(body.Articles) != 1 || !reflect.DeepEqual(body.Articles[0], want) {
+		t.Errorf("GetArticles() articles = %v, want [%v]", body.Articles, want)
+	}
+}
+
+func TestAPIHandler_GetArticles_UnknownField(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, _ := CreateTestAPIHandler(ctrl)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?fields=Title,Secret", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := handler.GetArticles(c, openapi.GetArticlesParams{}); err != nil {
+		t.Fatalf("GetArticles() error = %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("GetArticles() status = %v, want %v", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -14,12 +14,7 @@ func (h *APIHandler) GetCategories(ctx echo.Context) error {
 
 	output, err := h.getCategoriesUsecase.Exec(ctx.Request().Context(), input)
 	if err != nil {
-		ctx.Logger().Error("Failed to get categories: ", err)
-		errorMsg := presenter.ConvertErrorMessage(err)
-		return ctx.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get categories",
-			Detail: &errorMsg,
-		})
+		return h.errorRenderer.Render(ctx, "Failed to get categories", err)
 	}
 
 	return ctx.JSON(http.StatusOK, openapi.CategoriesResponse{
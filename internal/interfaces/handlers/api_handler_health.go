@@ -3,12 +3,21 @@ package handlers
 import (
 	"net/http"
 
-	"github.com/kozennoki/nerine/internal/openapi"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/kozennoki/nerine/internal/usecase"
 	"github.com/labstack/echo/v4"
 )
 
+// HealthCheck answers 200 as long as the server is running. An open upstream
+// circuit is reported as "degraded" rather than failing the check.
 func (h *APIHandler) HealthCheck(ctx echo.Context) error {
-	return ctx.JSON(http.StatusOK, openapi.HealthResponse{
-		Status: "ok",
+	output, err := h.getHealthUsecase.Exec(ctx.Request().Context(), usecase.GetHealthUsecaseInput{})
+	if err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to check health", err)
+	}
+
+	return ctx.JSON(http.StatusOK, presenter.HealthResponse{
+		Status:    string(output.Status),
+		Upstreams: presenter.ConvertUpstreamStatuses(output.Upstreams),
 	})
 }
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/usecase"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/mock/gomock"
 )
@@ -16,7 +18,16 @@ func TestAPIHandler_HealthCheck(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	handler, _ := CreateTestAPIHandler(ctrl)
+	handler, mocks := CreateTestAPIHandler(ctrl)
+
+	mocks.GetHealthUsecase.EXPECT().
+		Exec(gomock.Any(), usecase.GetHealthUsecaseInput{}).
+		Return(usecase.GetHealthUsecaseOutput{
+			Status: usecase.HealthStatusOK,
+			Upstreams: []*entity.UpstreamStatus{
+				{Name: "microcms", State: entity.CircuitClosed},
+			},
+		}, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
@@ -34,4 +45,46 @@ func TestAPIHandler_HealthCheck(t *testing.T) {
 	if !strings.Contains(rec.Body.String(), `"status":"ok"`) {
 		t.Errorf("HealthCheck() body should contain status ok, got %v", rec.Body.String())
 	}
+	if !strings.Contains(rec.Body.String(), `"upstreams":{"microcms":"closed"}`) {
+		t.Errorf("HealthCheck() body should contain upstream states, got %v", rec.Body.String())
+	}
+}
+
+func TestAPIHandler_HealthCheck_Degraded(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mocks := CreateTestAPIHandler(ctrl)
+
+	mocks.GetHealthUsecase.EXPECT().
+		Exec(gomock.Any(), usecase.GetHealthUsecaseInput{}).
+		Return(usecase.GetHealthUsecaseOutput{
+			Status: usecase.HealthStatusDegraded,
+			Upstreams: []*entity.UpstreamStatus{
+				{Name: "microcms", State: entity.CircuitClosed},
+				{Name: "zenn", State: entity.CircuitOpen},
+			},
+		}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.HealthCheck(c)
+
+	if err != nil {
+		t.Errorf("HealthCheck() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("HealthCheck() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), `"status":"degraded"`) {
+		t.Errorf("HealthCheck() body should contain status degraded, got %v", rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"zenn":"open"`) {
+		t.Errorf("HealthCheck() body should contain the open zenn circuit, got %v", rec.Body.String())
+	}
 }
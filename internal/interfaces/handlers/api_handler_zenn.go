@@ -27,11 +27,7 @@ func (h *APIHandler) GetZennArticles(c echo.Context, params openapi.GetZennArtic
 
 	output, err := h.getZennArticlesUsecase.Exec(c.Request().Context(), input)
 	if err != nil {
-		errMsg := presenter.ConvertErrorMessage(err)
-		return c.JSON(http.StatusInternalServerError, openapi.ErrorResponse{
-			Error:  "Failed to get Zenn articles",
-			Detail: &errMsg,
-		})
+		return h.errorRenderer.Render(c, "Failed to get Zenn articles", err)
 	}
 
 	articles := presenter.ConvertArticles(output.Articles)
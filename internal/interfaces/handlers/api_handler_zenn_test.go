@@ -193,7 +193,8 @@ func TestAPIHandler_GetZennArticles_UsecaseError(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	assert.Contains(t, rec.Body.String(), "Failed to get Zenn articles")
-	assert.Contains(t, rec.Body.String(), "usecase error")
+	assert.Contains(t, rec.Body.String(), "INTERNAL_ERROR")
+	assert.NotContains(t, rec.Body.String(), "usecase error")
 }
 
 func TestAPIHandler_GetZennArticles_EmptyResponse(t *testing.T) {
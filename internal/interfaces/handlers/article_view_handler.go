@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// botUserAgent matches the User-Agent of crawlers, link previews and HTTP
+// libraries, whose requests are not page views.
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|preview|fetch|headless|lighthouse|facebookexternalhit|embedly|curl|wget|python|go-http-client|java/|okhttp|axios|node-fetch`)
+
+// ArticleViewHandler ingests the page views that rank the popular articles.
+type ArticleViewHandler struct {
+	recordArticleViewUsecase usecase.RecordArticleViewUsecase
+	errorRenderer            *ErrorRenderer
+}
+
+func NewArticleViewHandler(
+	recordArticleViewUsecase usecase.RecordArticleViewUsecase,
+	errorRenderer *ErrorRenderer,
+) *ArticleViewHandler {
+	return &ArticleViewHandler{
+		recordArticleViewUsecase: recordArticleViewUsecase,
+		errorRenderer:            errorRenderer,
+	}
+}
+
+// Record counts a view of the article in the id path parameter. Views from
+// bots and prefetches are accepted but not counted, so that clients cannot
+// tell them apart. The reader is identified by the client IP and User-Agent,
+// never by anything the client chooses, so that a client cannot inflate the
+// count by varying it; any request body is ignored.
+func (h *ArticleViewHandler) Record(ctx echo.Context) error {
+	id := ctx.Param("id")
+	if id == "" {
+		return h.errorRenderer.RenderBadRequest(ctx, "Article ID is required", presenter.InvalidParam{Name: "id", Reason: "is required"})
+	}
+
+	req := ctx.Request()
+	if isBot(req) {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	_, err := h.recordArticleViewUsecase.Exec(req.Context(), usecase.RecordArticleViewUsecaseInput{
+		ArticleID: id,
+		Visitor:   ctx.RealIP() + "\x00" + req.UserAgent(),
+	})
+	if err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to record article view", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// isBot reports whether req is not a page view by a reader: a request from a
+// bot, or a prefetch that the reader may never look at.
+func isBot(req *http.Request) bool {
+	if req.Header.Get("Sec-Purpose") != "" || req.Header.Get("Purpose") == "prefetch" {
+		return true
+	}
+	ua := req.UserAgent()
+	return ua == "" || botUserAgent.MatchString(ua)
+}
@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/kozennoki/nerine/internal/usecase/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const browserUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15"
+
+func TestArticleViewHandler_Record(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		body        string
+		userAgent   string
+		header      map[string]string
+		wantVisitor string
+		mockError   error
+		wantStatus  int
+	}{
+		{
+			name:        "visitor from the client",
+			userAgent:   browserUserAgent,
+			wantVisitor: "192.0.2.1\x00" + browserUserAgent,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name:        "visitor ID in the body is ignored",
+			body:        `{"visitorId":"visitor-1"}`,
+			userAgent:   browserUserAgent,
+			wantVisitor: "192.0.2.1\x00" + browserUserAgent,
+			wantStatus:  http.StatusNoContent,
+		},
+		{
+			name:       "crawler is not counted",
+			userAgent:  "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "missing User-Agent is not counted",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "prefetch is not counted",
+			userAgent:  browserUserAgent,
+			header:     map[string]string{"Sec-Purpose": "prefetch"},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:        "unknown article",
+			userAgent:   browserUserAgent,
+			wantVisitor: "192.0.2.1\x00" + browserUserAgent,
+			mockError:   fmt.Errorf("failed to get article by ID: %w", repository.ErrNotFound),
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			recordArticleView := mocks.NewMockRecordArticleViewUsecase(ctrl)
+			if tt.wantVisitor != "" {
+				recordArticleView.EXPECT().
+					Exec(gomock.Any(), usecase.RecordArticleViewUsecaseInput{ArticleID: "article-1", Visitor: tt.wantVisitor}).
+					Return(usecase.RecordArticleViewUsecaseOutput{Counted: true}, tt.mockError)
+			}
+			handler := handlers.NewArticleViewHandler(recordArticleView, handlers.NewErrorRenderer(false))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/articles/article-1/views", strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			req.Header.Set("User-Agent", tt.userAgent)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("article-1")
+
+			err := handler.Record(c)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestArticleViewHandler_Record_RotatingVisitorID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	recordArticleView := mocks.NewMockRecordArticleViewUsecase(ctrl)
+	counted := make(map[string]bool)
+	recordArticleView.EXPECT().
+		Exec(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input usecase.RecordArticleViewUsecaseInput) (usecase.RecordArticleViewUsecaseOutput, error) {
+			// Stands in for the deduplication of the popularity store.
+			first := !counted[input.Visitor]
+			counted[input.Visitor] = true
+			return usecase.RecordArticleViewUsecaseOutput{Counted: first}, nil
+		}).
+		Times(4)
+	handler := handlers.NewArticleViewHandler(recordArticleView, handlers.NewErrorRenderer(false))
+
+	e := echo.New()
+	e.POST("/api/v1/articles/:id/views", handler.Record)
+	send := func(remoteAddr, visitorID string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/articles/article-1/views",
+			strings.NewReader(`{"visitorId":"`+visitorID+`"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("User-Agent", browserUserAgent)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	}
+
+	for i := range 3 {
+		send("192.0.2.1:1234", fmt.Sprintf("visitor-%d", i))
+	}
+	send("198.51.100.1:1234", "visitor-0")
+
+	assert.Len(t, counted, 2, "a client rotating its visitor ID must be counted once")
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	// bindErrorPrefix starts the messages of the parameter binding errors
+	// returned by the generated ServerInterfaceWrapper.
+	bindErrorPrefix = "Invalid format for parameter "
+)
+
+// errorStatuses maps repository errors to the status sent to clients.
+// Errors of any other kind are reported as 500.
+var errorStatuses = []struct {
+	err    error
+	status int
+}{
+	{err: repository.ErrNotFound, status: http.StatusNotFound},
+	{err: repository.ErrInvalidArgument, status: http.StatusBadRequest},
+	{err: repository.ErrRateLimited, status: http.StatusTooManyRequests},
+	{err: repository.ErrUpstreamUnavailable, status: http.StatusServiceUnavailable},
+}
+
+func errorStatus(err error) int {
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return e.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorRenderer writes error responses. Clients get a public message, an
+// error code and the request ID; the underlying error is logged with the
+// request's logger, which carries the same request ID, and only sent as
+// detail in debug mode.
+//
+// Clients that accept application/problem+json get an RFC 7807 document,
+// everyone else the ErrorResponse shape.
+type ErrorRenderer struct {
+	debug bool
+}
+
+func NewErrorRenderer(debug bool) *ErrorRenderer {
+	return &ErrorRenderer{
+		debug: debug,
+	}
+}
+
+// apiError is an error response before it is rendered in either format.
+type apiError struct {
+	status        int
+	message       string
+	invalidParams []presenter.InvalidParam
+	err           error
+}
+
+// Render responds to a failed usecase with the status matching the kind of err.
+func (r *ErrorRenderer) Render(ctx echo.Context, message string, err error) error {
+	return r.render(ctx, apiError{
+		status:  errorStatus(err),
+		message: message,
+		err:     err,
+	})
+}
+
+// RenderBadRequest responds with 400. The reasons of params are written by
+// the handler and always sent to the client.
+func (r *ErrorRenderer) RenderBadRequest(ctx echo.Context, message string, params ...presenter.InvalidParam) error {
+	return r.render(ctx, apiError{
+		status:        http.StatusBadRequest,
+		message:       message,
+		invalidParams: params,
+	})
+}
+
+// HandleError is an echo.HTTPErrorHandler for the errors returned by
+// handlers and middleware, including parameter binding errors and
+// recovered panics.
+func (r *ErrorRenderer) HandleError(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		return
+	}
+	if renderErr := r.render(ctx, newAPIError(err)); renderErr != nil {
+		logger.FromContext(ctx.Request().Context()).Error("Failed to render error response", zap.Error(renderErr))
+	}
+}
+
+func newAPIError(err error) apiError {
+	var he *echo.HTTPError
+	if !errors.As(err, &he) {
+		status := errorStatus(err)
+		return apiError{
+			status:  status,
+			message: http.StatusText(status),
+			err:     err,
+		}
+	}
+
+	message, ok := he.Message.(string)
+	if !ok {
+		message = http.StatusText(he.Code)
+	}
+	e := apiError{
+		status:  he.Code,
+		message: message,
+		err:     he.Internal,
+	}
+
+	if he.Code == http.StatusBadRequest && strings.HasPrefix(message, bindErrorPrefix) {
+		// The binding error text comes from strconv and friends, so only the
+		// parameter name is sent to the client.
+		name, _, _ := strings.Cut(strings.TrimPrefix(message, bindErrorPrefix), ":")
+		e.message = bindErrorPrefix + name
+		e.invalidParams = []presenter.InvalidParam{{Name: name, Reason: "invalid format"}}
+		e.err = errors.New(message)
+	}
+	return e
+}
+
+func (r *ErrorRenderer) render(ctx echo.Context, e apiError) error {
+	requestID := requestID(ctx)
+	r.log(ctx, e)
+
+	ctx.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	if ctx.Request().Method == http.MethodHead {
+		return ctx.NoContent(e.status)
+	}
+
+	if acceptsProblem(ctx.Request()) {
+		problem := presenter.Problem{
+			Type:          "about:blank",
+			Title:         http.StatusText(e.status),
+			Status:        e.status,
+			Detail:        e.message,
+			Instance:      ctx.Request().URL.Path,
+			Code:          presenter.ConvertErrorCode(e.status),
+			RequestID:     requestID,
+			InvalidParams: e.invalidParams,
+		}
+		if r.debug && e.err != nil {
+			problem.Detail = fmt.Sprintf("%s: %s", e.message, e.err)
+		}
+		ctx.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		return ctx.JSON(e.status, problem)
+	}
+
+	response := presenter.ErrorResponse{
+		Error:     e.message,
+		Code:      presenter.ConvertErrorCode(e.status),
+		RequestID: requestID,
+	}
+	if r.debug && e.err != nil {
+		detail := e.err.Error()
+		response.Detail = &detail
+	} else if len(e.invalidParams) > 0 {
+		reasons := make([]string, len(e.invalidParams))
+		for i, p := range e.invalidParams {
+			reasons[i] = p.Name + ": " + p.Reason
+		}
+		detail := strings.Join(reasons, "; ")
+		response.Detail = &detail
+	}
+	return ctx.JSON(e.status, response)
+}
+
+func (r *ErrorRenderer) log(ctx echo.Context, e apiError) {
+	fields := []zap.Field{
+		zap.Int("status", e.status),
+		zap.String("path", ctx.Path()),
+	}
+	if e.err != nil {
+		fields = append(fields, zap.Error(e.err))
+	}
+	requestLogger := logger.FromContext(ctx.Request().Context())
+	if e.status >= http.StatusInternalServerError {
+		requestLogger.Error(e.message, fields...)
+	} else {
+		requestLogger.Info(e.message, fields...)
+	}
+}
+
+// acceptsProblem reports whether the Accept header lists problem+json.
+func acceptsProblem(req *http.Request) bool {
+	for _, accept := range strings.Split(req.Header.Get(echo.HeaderAccept), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil || mediaType != MIMEApplicationProblemJSON {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// requestID prefers the ID set on the response by the RequestID middleware.
+func requestID(ctx echo.Context) string {
+	if id := ctx.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return ctx.Request().Header.Get(echo.HeaderXRequestID)
+}
@@ -0,0 +1,289 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErrorRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	upstreamErr := errors.New("microCMS API returned 401: X-MICROCMS-API-KEY header is invalid")
+
+	tests := []struct {
+		name       string
+		debug      bool
+		err        error
+		wantStatus int
+		wantCode   presenter.ErrorCode
+		wantDetail bool
+		wantLevel  zapcore.Level
+	}{
+		{
+			name:       "unknown error hides detail",
+			err:        fmt.Errorf("failed to get articles: %w", upstreamErr),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   presenter.ErrorCodeInternal,
+			wantLevel:  zapcore.ErrorLevel,
+		},
+		{
+			name:       "not found",
+			err:        fmt.Errorf("failed to get article by ID: %w", repository.ErrNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   presenter.ErrorCodeNotFound,
+			wantLevel:  zapcore.InfoLevel,
+		},
+		{
+			name:       "debug mode exposes detail",
+			debug:      true,
+			err:        fmt.Errorf("failed to get articles: %w", upstreamErr),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   presenter.ErrorCodeInternal,
+			wantDetail: true,
+			wantLevel:  zapcore.ErrorLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zapcore.DebugLevel)
+			renderer := handlers.NewErrorRenderer(tt.debug)
+
+			// The request's logger carries the request ID, as set by AccessLog.
+			requestLogger := zap.New(core).With(zap.String("request_id", "req-123"))
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles", nil)
+			req = req.WithContext(logger.NewContext(req.Context(), requestLogger))
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Response().Header().Set(echo.HeaderXRequestID, "req-123")
+
+			err := renderer.Render(c, "Failed to get articles", tt.err)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var response presenter.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "Failed to get articles", response.Error)
+			assert.Equal(t, tt.wantCode, response.Code)
+			assert.Equal(t, "req-123", response.RequestID)
+			if tt.wantDetail {
+				require.NotNil(t, response.Detail)
+				assert.Equal(t, tt.err.Error(), *response.Detail)
+			} else {
+				assert.Nil(t, response.Detail)
+				assert.NotContains(t, rec.Body.String(), "microCMS")
+			}
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.wantLevel, entries[0].Level)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, tt.err.Error(), fields["error"])
+			assert.Equal(t, "req-123", fields["request_id"])
+		})
+	}
+}
+
+func TestErrorRenderer_RenderBadRequest(t *testing.T) {
+	t.Parallel()
+
+	renderer := handlers.NewErrorRenderer(false)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles", nil)
+	req.Header.Set(echo.HeaderXRequestID, "client-id")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := renderer.RenderBadRequest(c, "Invalid fields parameter", presenter.InvalidParam{Name: "fields", Reason: `unknown field "foo"`})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response presenter.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, presenter.ErrorCodeInvalidRequest, response.Code)
+	assert.Equal(t, "client-id", response.RequestID)
+	require.NotNil(t, response.Detail)
+	assert.Equal(t, `fields: unknown field "foo"`, *response.Detail)
+}
+
+func TestErrorRenderer_Problem(t *testing.T) {
+	t.Parallel()
+
+	renderer := handlers.NewErrorRenderer(false)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/articles?fields=foo", nil)
+	req.Header.Set(echo.HeaderAccept, "application/json;q=0.5, application/problem+json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "req-123")
+
+	err := renderer.RenderBadRequest(c, "Invalid fields parameter", presenter.InvalidParam{Name: "fields", Reason: `unknown field "foo"`})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handlers.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAccept)
+
+	var problem presenter.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, presenter.Problem{
+		Type:          "about:blank",
+		Title:         "Bad Request",
+		Status:        http.StatusBadRequest,
+		Detail:        "Invalid fields parameter",
+		Instance:      "/api/v1/articles",
+		Code:          presenter.ErrorCodeInvalidRequest,
+		RequestID:     "req-123",
+		InvalidParams: []presenter.InvalidParam{{Name: "fields", Reason: `unknown field "foo"`}},
+	}, problem)
+}
+
+func TestErrorRenderer_HandleError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		accept        string
+		err           error
+		wantStatus    int
+		wantCode      presenter.ErrorCode
+		wantMessage   string
+		wantParams    []presenter.InvalidParam
+		wantNotInBody string
+	}{
+		{
+			name:        "middleware error",
+			accept:      handlers.MIMEApplicationProblemJSON,
+			err:         echo.NewHTTPError(http.StatusUnauthorized, "missing API key"),
+			wantStatus:  http.StatusUnauthorized,
+			wantCode:    presenter.ErrorCodeUnauthorized,
+			wantMessage: "missing API key",
+		},
+		{
+			name:          "binding error",
+			accept:        handlers.MIMEApplicationProblemJSON,
+			err:           echo.NewHTTPError(http.StatusBadRequest, `Invalid format for parameter limit: error binding string parameter: strconv.ParseInt: parsing "abc": invalid syntax`),
+			wantStatus:    http.StatusBadRequest,
+			wantCode:      presenter.ErrorCodeInvalidRequest,
+			wantMessage:   "Invalid format for parameter limit",
+			wantParams:    []presenter.InvalidParam{{Name: "limit", Reason: "invalid format"}},
+			wantNotInBody: "strconv",
+		},
+		{
+			name:          "unknown error",
+			accept:        handlers.MIMEApplicationProblemJSON,
+			err:           errors.New("runtime error: invalid memory address or nil pointer dereference"),
+			wantStatus:    http.StatusInternalServerError,
+			wantCode:      presenter.ErrorCodeInternal,
+			wantMessage:   "Internal Server Error",
+			wantNotInBody: "nil pointer",
+		},
+		{
+			name:        "repository error",
+			accept:      handlers.MIMEApplicationProblemJSON,
+			err:         fmt.Errorf("failed to get articles: %w", repository.ErrUpstreamUnavailable),
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    presenter.ErrorCodeUpstreamUnavailable,
+			wantMessage: "Service Unavailable",
+		},
+		{
+			name:        "legacy shape",
+			err:         echo.NewHTTPError(http.StatusUnauthorized, "invalid API key"),
+			wantStatus:  http.StatusUnauthorized,
+			wantCode:    presenter.ErrorCodeUnauthorized,
+			wantMessage: "invalid API key",
+		},
+		{
+			name:        "problem+json refused",
+			accept:      "application/problem+json;q=0, application/json",
+			err:         echo.NewHTTPError(http.StatusUnauthorized, "invalid API key"),
+			wantStatus:  http.StatusUnauthorized,
+			wantCode:    presenter.ErrorCodeUnauthorized,
+			wantMessage: "invalid API key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			renderer := handlers.NewErrorRenderer(false)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/articles", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			renderer.HandleError(tt.err, c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantNotInBody != "" {
+				assert.NotContains(t, rec.Body.String(), tt.wantNotInBody)
+			}
+
+			if rec.Header().Get(echo.HeaderContentType) != handlers.MIMEApplicationProblemJSON {
+				var response presenter.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantMessage, response.Error)
+				assert.Equal(t, tt.wantCode, response.Code)
+				assert.NotEqual(t, handlers.MIMEApplicationProblemJSON, tt.accept)
+				return
+			}
+
+			var problem presenter.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.wantStatus, problem.Status)
+			assert.Equal(t, http.StatusText(tt.wantStatus), problem.Title)
+			assert.Equal(t, tt.wantMessage, problem.Detail)
+			assert.Equal(t, tt.wantCode, problem.Code)
+			assert.Equal(t, tt.wantParams, problem.InvalidParams)
+		})
+	}
+}
+
+func TestErrorRenderer_HandleError_RecoveredPanic(t *testing.T) {
+	t.Parallel()
+
+	e := echo.New()
+	e.HTTPErrorHandler = handlers.NewErrorRenderer(false).HandleError
+	e.Use(echomiddleware.RecoverWithConfig(echomiddleware.RecoverConfig{DisablePrintStack: true}))
+	e.GET("/panic", func(c echo.Context) error {
+		panic("secret internal state")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(echo.HeaderAccept, handlers.MIMEApplicationProblemJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, handlers.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.NotContains(t, rec.Body.String(), "secret internal state")
+}
@@ -15,6 +15,7 @@ type TestAPIHandlerMocks struct {
 	GetArticlesByCategoryUsecase *mocks.MockGetArticlesByCategoryUsecase
 	GetCategoriesUsecase         *mocks.MockGetCategoriesUsecase
 	GetZennArticlesUsecase       *mocks.MockGetZennArticlesUsecase
+	GetHealthUsecase             *mocks.MockGetHealthUsecase
 }
 
 // CreateTestAPIHandler creates APIHandler with mocks for testing
@@ -27,6 +28,7 @@ func CreateTestAPIHandler(ctrl *gomock.Controller) (*handlers.APIHandler, *TestA
 		GetArticlesByCategoryUsecase: mocks.NewMockGetArticlesByCategoryUsecase(ctrl),
 		GetCategoriesUsecase:         mocks.NewMockGetCategoriesUsecase(ctrl),
 		GetZennArticlesUsecase:       mocks.NewMockGetZennArticlesUsecase(ctrl),
+		GetHealthUsecase:             mocks.NewMockGetHealthUsecase(ctrl),
 	}
 
 	handler := handlers.NewAPIHandler(
@@ -37,6 +39,8 @@ func CreateTestAPIHandler(ctrl *gomock.Controller) (*handlers.APIHandler, *TestA
 		mocks.GetArticlesByCategoryUsecase,
 		mocks.GetCategoriesUsecase,
 		mocks.GetZennArticlesUsecase,
+		mocks.GetHealthUsecase,
+		handlers.NewErrorRenderer(false),
 	)
 
 	return handler, mocks
@@ -46,3 +50,8 @@ func CreateTestAPIHandler(ctrl *gomock.Controller) (*handlers.APIHandler, *TestA
 func IntPtr(i int) *int {
 	return &i
 }
+
+// StringPtr is a helper function for creating string pointers
+func StringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevelHandler reads and changes the level of the running logger, so that
+// debug logging can be turned on without a redeploy.
+type LogLevelHandler struct {
+	level         zap.AtomicLevel
+	errorRenderer *ErrorRenderer
+}
+
+func NewLogLevelHandler(level zap.AtomicLevel, errorRenderer *ErrorRenderer) *LogLevelHandler {
+	return &LogLevelHandler{
+		level:         level,
+		errorRenderer: errorRenderer,
+	}
+}
+
+func (h *LogLevelHandler) Get(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusOK, presenter.LogLevelResponse{Level: h.level.String()})
+}
+
+func (h *LogLevelHandler) Set(ctx echo.Context) error {
+	var body presenter.LogLevelResponse
+	if err := ctx.Bind(&body); err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid log level payload")
+	}
+
+	// Levels above error would hide failures, so they are not accepted.
+	level, err := zapcore.ParseLevel(body.Level)
+	if err != nil || body.Level == "" || level > zapcore.ErrorLevel {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid log level",
+			presenter.InvalidParam{Name: "level", Reason: "must be one of debug, info, warn or error"})
+	}
+
+	previous := h.level.Level()
+	h.level.SetLevel(level)
+	// Logged at warn so that the change is recorded at every usual level.
+	logger.FromContext(ctx.Request().Context()).Warn("Log level changed",
+		zap.Stringer("from", previous),
+		zap.Stringer("to", level),
+	)
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusOK, presenter.LogLevelResponse{Level: level.String()})
+}
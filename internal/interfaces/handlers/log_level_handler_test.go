@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogLevelHandler_Get(t *testing.T) {
+	t.Parallel()
+
+	handler := handlers.NewLogLevelHandler(zap.NewAtomicLevelAt(zap.WarnLevel), handlers.NewErrorRenderer(false))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/log-level", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler.Get(e.NewContext(req, rec))
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+	assert.JSONEq(t, `{"level":"warn"}`, rec.Body.String())
+}
+
+func TestLogLevelHandler_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantLevel  zapcore.Level
+	}{
+		{name: "debug", body: `{"level":"debug"}`, wantStatus: http.StatusOK, wantLevel: zap.DebugLevel},
+		{name: "upper case", body: `{"level":"ERROR"}`, wantStatus: http.StatusOK, wantLevel: zap.ErrorLevel},
+		{name: "unknown level", body: `{"level":"verbose"}`, wantStatus: http.StatusBadRequest, wantLevel: zap.InfoLevel},
+		{name: "above error", body: `{"level":"fatal"}`, wantStatus: http.StatusBadRequest, wantLevel: zap.InfoLevel},
+		{name: "missing level", body: `{}`, wantStatus: http.StatusBadRequest, wantLevel: zap.InfoLevel},
+		{name: "invalid JSON", body: `{`, wantStatus: http.StatusBadRequest, wantLevel: zap.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			level := zap.NewAtomicLevelAt(zap.InfoLevel)
+			core, logs := observer.New(zap.DebugLevel)
+			handler := handlers.NewLogLevelHandler(level, handlers.NewErrorRenderer(false))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			requestLogger := zap.New(core).With(zap.String("request_id", "req-123"))
+			req = req.WithContext(logger.NewContext(req.Context(), requestLogger))
+			rec := httptest.NewRecorder()
+
+			err := handler.Set(e.NewContext(req, rec))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLevel, level.Level())
+
+			if tt.wantStatus != http.StatusOK {
+				assert.Zero(t, logs.FilterMessage("Log level changed").Len())
+				return
+			}
+			var body presenter.LogLevelResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantLevel.String(), body.Level)
+
+			changes := logs.FilterMessage("Log level changed").All()
+			require.Len(t, changes, 1)
+			assert.Equal(t, "info", changes[0].ContextMap()["from"])
+			assert.Equal(t, tt.wantLevel.String(), changes[0].ContextMap()["to"])
+			assert.Equal(t, "req-123", changes[0].ContextMap()["request_id"])
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/infrastructure/preview"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/kozennoki/nerine/internal/openapi"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// PreviewHandler issues encrypted preview links for article drafts and serves
+// the drafts to whoever holds one, so that reviewers need no API key.
+type PreviewHandler struct {
+	sealer                *preview.Sealer
+	getArticleByIDUsecase usecase.GetArticleByIDUsecase
+	errorRenderer         *ErrorRenderer
+	defaultTTL            time.Duration
+	maxTTL                time.Duration
+}
+
+func NewPreviewHandler(
+	sealer *preview.Sealer,
+	getArticleByIDUsecase usecase.GetArticleByIDUsecase,
+	errorRenderer *ErrorRenderer,
+	defaultTTL time.Duration,
+	maxTTL time.Duration,
+) *PreviewHandler {
+	return &PreviewHandler{
+		sealer:                sealer,
+		getArticleByIDUsecase: getArticleByIDUsecase,
+		errorRenderer:         errorRenderer,
+		defaultTTL:            defaultTTL,
+		maxTTL:                maxTTL,
+	}
+}
+
+// IssueToken seals a token for the requested draft. The draft is read first
+// so that a wrong article ID or draft key fails here rather than for the
+// reviewer.
+func (h *PreviewHandler) IssueToken(ctx echo.Context) error {
+	var body presenter.PreviewTokenRequest
+	if err := ctx.Bind(&body); err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid preview token payload")
+	}
+
+	var params []presenter.InvalidParam
+	if body.ArticleID == "" {
+		params = append(params, presenter.InvalidParam{Name: "articleId", Reason: "is required"})
+	}
+	if body.DraftKey == "" {
+		params = append(params, presenter.InvalidParam{Name: "draftKey", Reason: "is required"})
+	}
+	ttl := h.defaultTTL
+	if body.ExpiresIn != 0 {
+		ttl = time.Duration(body.ExpiresIn) * time.Second
+	}
+	if ttl <= 0 || ttl > h.maxTTL {
+		params = append(params, presenter.InvalidParam{
+			Name:   "expiresIn",
+			Reason: fmt.Sprintf("must be between 1 and %d seconds", int(h.maxTTL.Seconds())),
+		})
+	}
+	if len(params) > 0 {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid preview token request", params...)
+	}
+
+	_, err := h.getArticleByIDUsecase.Exec(ctx.Request().Context(), usecase.GetArticleByIDUsecaseInput{
+		ID:       body.ArticleID,
+		DraftKey: body.DraftKey,
+	})
+	if err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to get article", err)
+	}
+
+	token, issued, err := h.sealer.Seal(body.ArticleID, body.DraftKey, ttl)
+	if err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to issue preview token", err)
+	}
+
+	logger.FromContext(ctx.Request().Context()).Info("Preview token issued",
+		zap.String("article_id", issued.ArticleID),
+		zap.Time("expires_at", issued.ExpiresAt),
+	)
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(http.StatusCreated, presenter.PreviewTokenResponse{
+		Token:     token,
+		ExpiresAt: issued.ExpiresAt,
+	})
+}
+
+// GetArticle serves the draft named by the token verified by
+// middleware.PreviewToken.
+func (h *PreviewHandler) GetArticle(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	token, ok := middleware.PreviewTokenFrom(ctx)
+	if !ok {
+		return h.errorRenderer.Render(ctx, "Failed to get article", errors.New("preview token not verified"))
+	}
+
+	output, err := h.getArticleByIDUsecase.Exec(ctx.Request().Context(), usecase.GetArticleByIDUsecaseInput{
+		ID:       token.ArticleID,
+		DraftKey: token.DraftKey,
+	})
+	if err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to get article", err)
+	}
+
+	return ctx.JSON(http.StatusOK, openapi.ArticleResponse{
+		Article: presenter.ConvertArticle(output.Article),
+	})
+}
@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/infrastructure/preview"
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/kozennoki/nerine/internal/openapi"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/kozennoki/nerine/internal/usecase/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newPreviewServer(t *testing.T) (*echo.Echo, *mocks.MockGetArticleByIDUsecase) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	getArticleByID := mocks.NewMockGetArticleByIDUsecase(ctrl)
+	sealer := preview.NewSealer("0123456789abcdef0123456789abcdef")
+	handler := handlers.NewPreviewHandler(sealer, getArticleByID, handlers.NewErrorRenderer(false), time.Hour, 24*time.Hour)
+
+	e := echo.New()
+	e.POST("/preview/tokens", handler.IssueToken)
+	e.GET("/preview/articles/:id", handler.GetArticle, middleware.PreviewToken(sealer))
+	return e, getArticleByID
+}
+
+func issuePreviewToken(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/preview/tokens", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPreviewHandler_IssueAndOpen(t *testing.T) {
+	t.Parallel()
+
+	e, getArticleByID := newPreviewServer(t)
+	draft := usecase.GetArticleByIDUsecaseOutput{Article: &entity.Article{ID: "article-1", Title: "Draft"}}
+	getArticleByID.EXPECT().
+		Exec(gomock.Any(), usecase.GetArticleByIDUsecaseInput{ID: "article-1", DraftKey: "draft-key"}).
+		Return(draft, nil).
+		Times(2)
+
+	start := time.Now()
+	rec := issuePreviewToken(e, `{"articleId":"article-1","draftKey":"draft-key","expiresIn":600}`)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+	var issued presenter.PreviewTokenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &issued))
+	assert.WithinDuration(t, start.Add(10*time.Minute), issued.ExpiresAt, 2*time.Second)
+
+	req := httptest.NewRequest(http.MethodGet, "/preview/articles/article-1?token="+url.QueryEscape(issued.Token), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+	var body openapi.ArticleResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "Draft", body.Article.Title)
+
+	// The token does not open any other article.
+	req = httptest.NewRequest(http.MethodGet, "/preview/articles/article-2?token="+url.QueryEscape(issued.Token), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestPreviewHandler_IssueToken_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing article ID", body: `{"draftKey":"draft-key"}`},
+		{name: "missing draft key", body: `{"articleId":"article-1"}`},
+		{name: "negative lifetime", body: `{"articleId":"article-1","draftKey":"draft-key","expiresIn":-1}`},
+		{name: "lifetime above the maximum", body: `{"articleId":"article-1","draftKey":"draft-key","expiresIn":86401}`},
+		{name: "invalid JSON", body: `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e, _ := newPreviewServer(t)
+
+			rec := issuePreviewToken(e, tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestPreviewHandler_IssueToken_UnknownDraft(t *testing.T) {
+	t.Parallel()
+
+	e, getArticleByID := newPreviewServer(t)
+	getArticleByID.EXPECT().
+		Exec(gomock.Any(), usecase.GetArticleByIDUsecaseInput{ID: "article-1", DraftKey: "wrong"}).
+		Return(usecase.GetArticleByIDUsecaseOutput{}, fmt.Errorf("failed to get draft article by ID: %w", repository.ErrNotFound))
+
+	rec := issuePreviewToken(e, `{"articleId":"article-1","draftKey":"wrong"}`)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "token")
+}
+
+func TestPreviewHandler_GetArticle_DraftGone(t *testing.T) {
+	t.Parallel()
+
+	e, getArticleByID := newPreviewServer(t)
+	gomock.InOrder(
+		getArticleByID.EXPECT().
+			Exec(gomock.Any(), usecase.GetArticleByIDUsecaseInput{ID: "article-1", DraftKey: "draft-key"}).
+			Return(usecase.GetArticleByIDUsecaseOutput{Article: &entity.Article{ID: "article-1"}}, nil),
+		getArticleByID.EXPECT().
+			Exec(gomock.Any(), usecase.GetArticleByIDUsecaseInput{ID: "article-1", DraftKey: "draft-key"}).
+			Return(usecase.GetArticleByIDUsecaseOutput{}, fmt.Errorf("failed to get draft article by ID: %w", repository.ErrNotFound)),
+	)
+
+	rec := issuePreviewToken(e, `{"articleId":"article-1","draftKey":"draft-key"}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var issued presenter.PreviewTokenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &issued))
+
+	req := httptest.NewRequest(http.MethodGet, "/preview/articles/article-1?token="+url.QueryEscape(issued.Token), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+}
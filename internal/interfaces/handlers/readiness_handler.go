@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// ReadinessHandler serves /ready. Unlike the /health liveness check it
+// probes the upstreams, so it answers 503 while a required one is down.
+// The errors of the upstreams are only sent as detail in debug mode.
+type ReadinessHandler struct {
+	getReadinessUsecase usecase.GetReadinessUsecase
+	errorRenderer       *ErrorRenderer
+	debug               bool
+}
+
+func NewReadinessHandler(
+	getReadinessUsecase usecase.GetReadinessUsecase,
+	errorRenderer *ErrorRenderer,
+	debug bool,
+) *ReadinessHandler {
+	return &ReadinessHandler{
+		getReadinessUsecase: getReadinessUsecase,
+		errorRenderer:       errorRenderer,
+		debug:               debug,
+	}
+}
+
+func (h *ReadinessHandler) Ready(ctx echo.Context) error {
+	output, err := h.getReadinessUsecase.Exec(ctx.Request().Context(), usecase.GetReadinessUsecaseInput{})
+	if err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to check readiness", err)
+	}
+
+	response := presenter.ReadinessResponse{
+		Status:       string(output.Status),
+		Dependencies: make(map[string]presenter.DependencyResponse, len(output.Dependencies)),
+	}
+	for _, dep := range output.Dependencies {
+		response.Dependencies[dep.Name] = h.convertDependency(ctx, dep)
+	}
+
+	status := http.StatusOK
+	if output.Status != usecase.ReadinessStatusReady {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.JSON(status, response)
+}
+
+func (h *ReadinessHandler) convertDependency(ctx echo.Context, dep *entity.DependencyStatus) presenter.DependencyResponse {
+	response := presenter.DependencyResponse{
+		Status:    presenter.DependencyStatusUp,
+		Required:  dep.Required,
+		LatencyMs: dep.Latency.Milliseconds(),
+		CheckedAt: dep.CheckedAt,
+	}
+	if !dep.Healthy {
+		response.Status = presenter.DependencyStatusDown
+		logger.FromContext(ctx.Request().Context()).Warn("Dependency is down",
+			zap.String("dependency", dep.Name),
+			zap.Bool("required", dep.Required),
+			zap.Error(dep.LastError),
+		)
+	}
+	if dep.LastError != nil {
+		response.LastError = &presenter.DependencyError{
+			Code: presenter.ConvertErrorCode(errorStatus(dep.LastError)),
+			At:   dep.LastErrorAt,
+		}
+		if h.debug {
+			detail := dep.LastError.Error()
+			response.LastError.Detail = &detail
+		}
+	}
+	return response
+}
@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/kozennoki/nerine/internal/usecase/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadinessHandler_Ready(t *testing.T) {
+	t.Parallel()
+
+	checkedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	probeErr := fmt.Errorf("failed to get articles: %w", repository.ErrUpstreamUnavailable)
+
+	tests := []struct {
+		name       string
+		debug      bool
+		output     usecase.GetReadinessUsecaseOutput
+		wantStatus int
+		wantBody   presenter.ReadinessResponse
+	}{
+		{
+			name: "ready",
+			output: usecase.GetReadinessUsecaseOutput{
+				Status: usecase.ReadinessStatusReady,
+				Dependencies: []*entity.DependencyStatus{
+					{Name: "microcms", Required: true, Healthy: true, Latency: 120 * time.Millisecond, CheckedAt: checkedAt},
+					{Name: "zenn", Healthy: false, Latency: 3 * time.Second, CheckedAt: checkedAt, LastError: probeErr, LastErrorAt: checkedAt},
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: presenter.ReadinessResponse{
+				Status: "ready",
+				Dependencies: map[string]presenter.DependencyResponse{
+					"microcms": {Status: presenter.DependencyStatusUp, Required: true, LatencyMs: 120, CheckedAt: checkedAt},
+					"zenn": {
+						Status:    presenter.DependencyStatusDown,
+						LatencyMs: 3000,
+						CheckedAt: checkedAt,
+						LastError: &presenter.DependencyError{Code: presenter.ErrorCodeUpstreamUnavailable, At: checkedAt},
+					},
+				},
+			},
+		},
+		{
+			name:  "not ready with debug detail",
+			debug: true,
+			output: usecase.GetReadinessUsecaseOutput{
+				Status: usecase.ReadinessStatusNotReady,
+				Dependencies: []*entity.DependencyStatus{
+					{Name: "microcms", Required: true, Healthy: false, Latency: 5 * time.Millisecond, CheckedAt: checkedAt, LastError: probeErr, LastErrorAt: checkedAt},
+				},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: presenter.ReadinessResponse{
+				Status: "not_ready",
+				Dependencies: map[string]presenter.DependencyResponse{
+					"microcms": {
+						Status:    presenter.DependencyStatusDown,
+						Required:  true,
+						LatencyMs: 5,
+						CheckedAt: checkedAt,
+						LastError: &presenter.DependencyError{
+							Code:   presenter.ErrorCodeUpstreamUnavailable,
+							Detail: StringPtr(probeErr.Error()),
+							At:     checkedAt,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			readinessUsecase := mocks.NewMockGetReadinessUsecase(ctrl)
+			readinessUsecase.EXPECT().
+				Exec(gomock.Any(), usecase.GetReadinessUsecaseInput{}).
+				Return(tt.output, nil)
+
+			handler := handlers.NewReadinessHandler(readinessUsecase, handlers.NewErrorRenderer(false), tt.debug)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ready", nil), rec)
+
+			require.NoError(t, handler.Ready(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+
+			var body presenter.ReadinessResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
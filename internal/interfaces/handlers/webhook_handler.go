@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// microCMS API endpoints whose changes affect cached content.
+const (
+	microCMSArticlesAPI   = "blog"
+	microCMSCategoriesAPI = "categories"
+)
+
+type WebhookHandler struct {
+	purgeContentUsecase usecase.PurgeContentUsecase
+	errorRenderer       *ErrorRenderer
+}
+
+func NewWebhookHandler(
+	purgeContentUsecase usecase.PurgeContentUsecase,
+	errorRenderer *ErrorRenderer,
+) *WebhookHandler {
+	return &WebhookHandler{
+		purgeContentUsecase: purgeContentUsecase,
+		errorRenderer:       errorRenderer,
+	}
+}
+
+// microCMSWebhookPayload is the body of a microCMS content change webhook.
+type microCMSWebhookPayload struct {
+	Service  string `json:"service"`
+	API      string `json:"api"`
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Contents struct {
+		Old *microCMSWebhookContent `json:"old"`
+		New *microCMSWebhookContent `json:"new"`
+	} `json:"contents"`
+}
+
+type microCMSWebhookContent struct {
+	ID           string                  `json:"id"`
+	PublishValue *microCMSWebhookArticle `json:"publishValue"`
+}
+
+type microCMSWebhookArticle struct {
+	Category *struct {
+		ID string `json:"id"`
+	} `json:"category"`
+}
+
+// MicroCMS purges the cache entries affected by a content change.
+// The signature is verified by middleware before this handler runs.
+func (h *WebhookHandler) MicroCMS(ctx echo.Context) error {
+	var payload microCMSWebhookPayload
+	if err := ctx.Bind(&payload); err != nil {
+		return h.errorRenderer.RenderBadRequest(ctx, "Invalid webhook payload")
+	}
+
+	input := usecase.PurgeContentUsecaseInput{
+		ID: payload.ID,
+	}
+	switch payload.API {
+	case microCMSArticlesAPI:
+		input.Kind = usecase.ContentKindArticle
+		input.CategorySlugs = payload.categorySlugs()
+	case microCMSCategoriesAPI:
+		input.Kind = usecase.ContentKindCategory
+	}
+
+	if _, err := h.purgeContentUsecase.Exec(ctx.Request().Context(), input); err != nil {
+		return h.errorRenderer.Render(ctx, "Failed to purge content", err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// categorySlugs returns the published categories before and after the change.
+func (p microCMSWebhookPayload) categorySlugs() []string {
+	var slugs []string
+	for _, content := range []*microCMSWebhookContent{p.Contents.Old, p.Contents.New} {
+		if content == nil || content.PublishValue == nil || content.PublishValue.Category == nil {
+			continue
+		}
+		slug := content.PublishValue.Category.ID
+		if slug == "" || (len(slugs) > 0 && slugs[0] == slug) {
+			continue
+		}
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/interfaces/handlers"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/kozennoki/nerine/internal/usecase/mocks"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookHandler_MicroCMS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedInput  *usecase.PurgeContentUsecaseInput
+		mockError      error
+		expectedStatus int
+	}{
+		{
+			name: "Article moved to another category",
+			body: `{
+				"service": "nerine", "api": "blog", "id": "article-1", "type": "edit",
+				"contents": {
+					"old": {"id": "article-1", "publishValue": {"category": {"id": "go"}}},
+					"new": {"id": "article-1", "publishValue": {"category": {"id": "golang"}}}
+				}
+			}`,
+			expectedInput: &usecase.PurgeContentUsecaseInput{
+				Kind:          usecase.ContentKindArticle,
+				ID:            "article-1",
+				CategorySlugs: []string{"go", "golang"},
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "New article in the same category",
+			body: `{
+				"api": "blog", "id": "article-2", "type": "new",
+				"contents": {
+					"old": null,
+					"new": {"id": "article-2", "publishValue": {"category": {"id": "go"}}}
+				}
+			}`,
+			expectedInput: &usecase.PurgeContentUsecaseInput{
+				Kind:          usecase.ContentKindArticle,
+				ID:            "article-2",
+				CategorySlugs: []string{"go"},
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "Category change",
+			body: `{"api": "categories", "id": "go", "type": "edit", "contents": {}}`,
+			expectedInput: &usecase.PurgeContentUsecaseInput{
+				Kind: usecase.ContentKindCategory,
+				ID:   "go",
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Invalid payload",
+			body:           `{"api":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Error from usecase",
+			body: `{"api": "categories", "id": "go", "type": "delete"}`,
+			expectedInput: &usecase.PurgeContentUsecaseInput{
+				Kind: usecase.ContentKindCategory,
+				ID:   "go",
+			},
+			mockError:      errors.New("purge failed"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			purgeContentUsecase := mocks.NewMockPurgeContentUsecase(ctrl)
+			if tt.expectedInput != nil {
+				purgeContentUsecase.EXPECT().
+					Exec(gomock.Any(), *tt.expectedInput).
+					Return(usecase.PurgeContentUsecaseOutput{Purged: true}, tt.mockError)
+			}
+			handler := handlers.NewWebhookHandler(purgeContentUsecase, handlers.NewErrorRenderer(false))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/microcms", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.MicroCMS(c)
+
+			if err != nil {
+				t.Errorf("MicroCMS() error = %v", err)
+			}
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("MicroCMS() status = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// AccessLog injects a request-scoped logger carrying the request ID, method,
+// route and, for traced requests, the trace ID into the request context, and
+// writes one access log line per request once the response has been sent.
+// It must run after the RequestID and tracing middleware.
+//
+// Errors returned by the handler are rendered here so that the logged status
+// is the one sent to the client.
+func AccessLog(base *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+			res := c.Response()
+
+			fields := []zap.Field{
+				zap.String("request_id", res.Header().Get(echo.HeaderXRequestID)),
+				zap.String("method", req.Method),
+				zap.String("route", c.Path()),
+			}
+			if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+				fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+			}
+			requestLogger := base.With(fields...)
+			c.SetRequest(req.WithContext(logger.NewContext(req.Context(), requestLogger)))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			requestLogger.Info("Access",
+				zap.String("path", req.URL.Path),
+				zap.Int("status", res.Status),
+				zap.Duration("latency", time.Since(start)),
+				zap.Int64("bytes", res.Size),
+				zap.String("api_key", APIKeyName(c)),
+				zap.String("cache", res.Header().Get(HeaderCacheStatus)),
+				zap.String("remote_ip", c.RealIP()),
+				zap.String("user_agent", req.UserAgent()),
+			)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newAccessLogServer(t *testing.T) (*echo.Echo, *observer.ObservedLogs) {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	e := echo.New()
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.AccessLog(zap.New(core)))
+	e.Use(middleware.APIKeyAuth(middleware.NewKeyRing([]middleware.APIKey{
+		{Name: "web", SHA256: middleware.HashAPIKey("valid-api-key"), Scopes: []middleware.Scope{middleware.ScopeRead}},
+	}), middleware.ScopeRead))
+
+	e.GET("/articles/:id", func(c echo.Context) error {
+		logger.FromContext(c.Request().Context()).Info("Fetching article")
+		c.Response().Header().Set(middleware.HeaderCacheStatus, "HIT")
+		return c.String(http.StatusOK, "article")
+	})
+	e.GET("/broken", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+	return e, logs
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+
+	e, logs := newAccessLogServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/1", nil)
+	req.Header.Set("X-API-Key", "valid-api-key")
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code 200, got: %d", rec.Code)
+	}
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected a handler log and an access log, got: %d entries", len(entries))
+	}
+
+	handlerFields := entries[0].ContextMap()
+	if handlerFields["request_id"] != "req-123" || handlerFields["route"] != "/articles/:id" || handlerFields["api_key"] != "web" {
+		t.Errorf("Expected the handler log to carry the request fields, got: %v", handlerFields)
+	}
+
+	access := entries[1]
+	if access.Message != "Access" {
+		t.Errorf("Expected the access log last, got: %s", access.Message)
+	}
+	fields := access.ContextMap()
+	want := map[string]any{
+		"request_id": "req-123",
+		"method":     http.MethodGet,
+		"route":      "/articles/:id",
+		"path":       "/articles/1",
+		"status":     int64(http.StatusOK),
+		"bytes":      int64(len("article")),
+		"api_key":    "web",
+		"cache":      "HIT",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("Expected %s to be %v, got: %v", key, value, fields[key])
+		}
+	}
+	if _, ok := fields["latency"]; !ok {
+		t.Error("Expected the latency to be logged")
+	}
+}
+
+func TestAccessLog_LogsRenderedErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	e, logs := newAccessLogServer(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		apiKey string
+		want   int
+	}{
+		{name: "handler error", path: "/broken", apiKey: "valid-api-key", want: http.StatusInternalServerError},
+		{name: "rejected API key", path: "/articles/1", apiKey: "wrong", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("X-API-Key", tt.apiKey)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected status code %d, got: %d", tt.name, tt.want, rec.Code)
+		}
+
+		entries := logs.TakeAll()
+		if len(entries) != 1 {
+			t.Fatalf("%s: expected a single access log, got: %d entries", tt.name, len(entries))
+		}
+		fields := entries[0].ContextMap()
+		if fields["status"] != int64(tt.want) {
+			t.Errorf("%s: expected the logged status to be %d, got: %v", tt.name, tt.want, fields["status"])
+		}
+		if fields["request_id"] == "" {
+			t.Errorf("%s: expected a generated request ID", tt.name)
+		}
+	}
+}
+
+func TestAccessLog_TraceID(t *testing.T) {
+	t.Parallel()
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := trace.ContextWithSpanContext(c.Request().Context(), spanContext)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	e.Use(middleware.AccessLog(zap.New(core)))
+	e.GET("/health", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected a single access log, got: %d entries", len(entries))
+	}
+	if got := entries[0].ContextMap()["trace_id"]; got != traceID.String() {
+		t.Errorf("Expected trace_id %s, got: %v", traceID, got)
+	}
+}
@@ -1,23 +1,133 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"net/http"
+	"slices"
+	"time"
 
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 )
 
-func APIKeyAuth(apiKey string) echo.MiddlewareFunc {
+const (
+	HeaderAPIKey = "X-API-Key"
+
+	// apiKeyNameKey is the echo context key under which APIKeyAuth stores
+	// the name of the key that authenticated the request.
+	apiKeyNameKey = "apiKeyName"
+	// apiKeyScopeKey holds the scope the request was authorized for.
+	apiKeyScopeKey = "apiKeyScope"
+)
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+const (
+	// ScopeRead allows the published content endpoints.
+	ScopeRead Scope = "read"
+	// ScopePreview allows reading drafts.
+	ScopePreview Scope = "preview"
+	// ScopeAdmin allows the operational endpoints, such as the log level.
+	ScopeAdmin Scope = "admin"
+)
+
+// Errors returned when the API key is rejected.
+var (
+	errMissingAPIKey     = echo.NewHTTPError(http.StatusUnauthorized, "missing API key")
+	errInvalidAPIKey     = echo.NewHTTPError(http.StatusUnauthorized, "invalid API key")
+	errExpiredAPIKey     = echo.NewHTTPError(http.StatusUnauthorized, "expired API key")
+	errInsufficientScope = echo.NewHTTPError(http.StatusForbidden, "insufficient scope")
+)
+
+// APIKey is a named key of the key ring. Only the SHA-256 digest of the key
+// is kept. A zero ExpiresAt never expires.
+type APIKey struct {
+	Name      string
+	SHA256    [sha256.Size]byte
+	Scopes    []Scope
+	ExpiresAt time.Time
+}
+
+// HashAPIKey returns the SHA-256 digest under which key is stored.
+func HashAPIKey(key string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(key))
+}
+
+// KeyRing holds the API keys accepted by APIKeyAuth. Several keys can be
+// valid at once, so a key is rotated by adding the new one, moving the
+// clients over and then removing or expiring the old one.
+type KeyRing struct {
+	keys []APIKey
+	now  func() time.Time
+}
+
+func NewKeyRing(keys []APIKey) *KeyRing {
+	return &KeyRing{
+		keys: keys,
+		now:  time.Now,
+	}
+}
+
+// lookup returns the key whose digest matches key. Every digest is compared
+// in constant time, whether or not an earlier one matched; digests are
+// unique, so at most one does.
+func (r *KeyRing) lookup(key string) (APIKey, bool) {
+	digest := HashAPIKey(key)
+	var found APIKey
+	ok := false
+	for _, k := range r.keys {
+		if subtle.ConstantTimeCompare(digest[:], k.SHA256[:]) == 1 {
+			found, ok = k, true
+		}
+	}
+	return found, ok
+}
+
+// APIKeyName returns the name of the API key that authenticated the request,
+// or an empty string for unauthenticated routes.
+func APIKeyName(c echo.Context) string {
+	name, _ := c.Get(apiKeyNameKey).(string)
+	return name
+}
+
+// APIKeyScope returns the scope the request was authorized for, or an empty
+// scope for unauthenticated routes.
+func APIKeyScope(c echo.Context) Scope {
+	scope, _ := c.Get(apiKeyScopeKey).(Scope)
+	return scope
+}
+
+// APIKeyAuth accepts requests whose X-API-Key is an unexpired key of ring
+// with scope. The key name is stored for APIKeyName and added to the
+// request-scoped logger.
+func APIKeyAuth(ring *KeyRing, scope Scope) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			requestAPIKey := c.Request().Header.Get("X-API-Key")
+			requestAPIKey := c.Request().Header.Get(HeaderAPIKey)
 			if requestAPIKey == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "missing API key")
+				return errMissingAPIKey
 			}
 
-			if requestAPIKey != apiKey {
-				return echo.NewHTTPError(http.StatusUnauthorized, "invalid API key")
+			key, ok := ring.lookup(requestAPIKey)
+			if !ok {
+				return errInvalidAPIKey
+			}
+
+			c.Set(apiKeyNameKey, key.Name)
+			if !key.ExpiresAt.IsZero() && !ring.now().Before(key.ExpiresAt) {
+				return errExpiredAPIKey
+			}
+			if !slices.Contains(key.Scopes, scope) {
+				return errInsufficientScope
 			}
 
+			c.Set(apiKeyScopeKey, scope)
+			ctx := c.Request().Context()
+			requestLogger := logger.FromContext(ctx).With(zap.String("api_key", key.Name))
+			c.SetRequest(c.Request().WithContext(logger.NewContext(ctx, requestLogger)))
 			return next(c)
 		}
 	}
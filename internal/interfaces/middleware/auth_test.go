@@ -4,16 +4,24 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/kozennoki/nerine/internal/interfaces/middleware"
 	"github.com/labstack/echo/v4"
 )
 
+// newKeyRing returns a ring holding key as "web" with the read scope.
+func newKeyRing(key string) *middleware.KeyRing {
+	return middleware.NewKeyRing([]middleware.APIKey{
+		{Name: "web", SHA256: middleware.HashAPIKey(key), Scopes: []middleware.Scope{middleware.ScopeRead}},
+	})
+}
+
 func TestAPIKeyAuth_ValidAPIKey(t *testing.T) {
 	t.Parallel()
 
 	expectedAPIKey := "valid-api-key"
-	middlewareFunc := middleware.APIKeyAuth(expectedAPIKey)
+	middlewareFunc := middleware.APIKeyAuth(newKeyRing(expectedAPIKey), middleware.ScopeRead)
 
 	// Create a mock handler
 	mockHandler := func(c echo.Context) error {
@@ -42,13 +50,17 @@ func TestAPIKeyAuth_ValidAPIKey(t *testing.T) {
 	if rec.Body.String() != "success" {
 		t.Errorf("Expected body 'success', got: %s", rec.Body.String())
 	}
+
+	if got := middleware.APIKeyName(c); got != "web" {
+		t.Errorf("Expected API key name 'web', got: %s", got)
+	}
 }
 
 func TestAPIKeyAuth_MissingAPIKey(t *testing.T) {
 	t.Parallel()
 
 	expectedAPIKey := "valid-api-key"
-	middleware := middleware.APIKeyAuth(expectedAPIKey)
+	middleware := middleware.APIKeyAuth(newKeyRing(expectedAPIKey), middleware.ScopeRead)
 
 	// Create a mock handler
 	mockHandler := func(c echo.Context) error {
@@ -86,7 +98,7 @@ func TestAPIKeyAuth_InvalidAPIKey(t *testing.T) {
 	t.Parallel()
 
 	expectedAPIKey := "valid-api-key"
-	middleware := middleware.APIKeyAuth(expectedAPIKey)
+	middleware := middleware.APIKeyAuth(newKeyRing(expectedAPIKey), middleware.ScopeRead)
 
 	// Create a mock handler
 	mockHandler := func(c echo.Context) error {
@@ -125,7 +137,7 @@ func TestAPIKeyAuth_EmptyAPIKey(t *testing.T) {
 	t.Parallel()
 
 	expectedAPIKey := "valid-api-key"
-	middleware := middleware.APIKeyAuth(expectedAPIKey)
+	middleware := middleware.APIKeyAuth(newKeyRing(expectedAPIKey), middleware.ScopeRead)
 
 	// Create a mock handler
 	mockHandler := func(c echo.Context) error {
@@ -164,7 +176,7 @@ func TestAPIKeyAuth_CaseSensitive(t *testing.T) {
 	t.Parallel()
 
 	expectedAPIKey := "Valid-API-Key"
-	middleware := middleware.APIKeyAuth(expectedAPIKey)
+	middleware := middleware.APIKeyAuth(newKeyRing(expectedAPIKey), middleware.ScopeRead)
 
 	// Create a mock handler
 	mockHandler := func(c echo.Context) error {
@@ -198,3 +210,62 @@ func TestAPIKeyAuth_CaseSensitive(t *testing.T) {
 		t.Errorf("Expected echo.HTTPError, got: %T", err)
 	}
 }
+
+func TestAPIKeyAuth_KeyRing(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	ring := middleware.NewKeyRing([]middleware.APIKey{
+		{Name: "web", SHA256: middleware.HashAPIKey("web-key"), Scopes: []middleware.Scope{middleware.ScopeRead}},
+		{Name: "web-next", SHA256: middleware.HashAPIKey("web-next-key"), Scopes: []middleware.Scope{middleware.ScopeRead}},
+		{Name: "web-old", SHA256: middleware.HashAPIKey("web-old-key"), Scopes: []middleware.Scope{middleware.ScopeRead}, ExpiresAt: now},
+		{Name: "web-expiring", SHA256: middleware.HashAPIKey("web-expiring-key"), Scopes: []middleware.Scope{middleware.ScopeRead}, ExpiresAt: now.Add(time.Hour)},
+		{Name: "ops", SHA256: middleware.HashAPIKey("ops-key"), Scopes: []middleware.Scope{middleware.ScopeRead, middleware.ScopeAdmin}},
+	})
+	ring.SetNow(func() time.Time { return now })
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		scope      middleware.Scope
+		wantStatus int
+		wantName   string
+	}{
+		{name: "current key", apiKey: "web-key", scope: middleware.ScopeRead, wantStatus: http.StatusOK, wantName: "web"},
+		{name: "next key during rotation", apiKey: "web-next-key", scope: middleware.ScopeRead, wantStatus: http.StatusOK, wantName: "web-next"},
+		{name: "key before expiry", apiKey: "web-expiring-key", scope: middleware.ScopeRead, wantStatus: http.StatusOK, wantName: "web-expiring"},
+		{name: "expired key", apiKey: "web-old-key", scope: middleware.ScopeRead, wantStatus: http.StatusUnauthorized, wantName: "web-old"},
+		{name: "missing scope", apiKey: "web-key", scope: middleware.ScopeAdmin, wantStatus: http.StatusForbidden, wantName: "web"},
+		{name: "admin scope", apiKey: "ops-key", scope: middleware.ScopeAdmin, wantStatus: http.StatusOK, wantName: "ops"},
+		{name: "unknown key", apiKey: "other-key", scope: middleware.ScopeRead, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(middleware.HeaderAPIKey, tt.apiKey)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := middleware.APIKeyAuth(ring, tt.scope)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})(c)
+
+			status := rec.Code
+			if httpErr, ok := err.(*echo.HTTPError); ok {
+				status = httpErr.Code
+			} else if err != nil {
+				t.Fatalf("Expected echo.HTTPError, got: %T", err)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Expected status code %d, got: %d", tt.wantStatus, status)
+			}
+			if got := middleware.APIKeyName(c); got != tt.wantName {
+				t.Errorf("Expected API key name %q, got: %q", tt.wantName, got)
+			}
+		})
+	}
+}
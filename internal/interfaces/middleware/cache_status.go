@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderCacheStatus = "X-Cache"
+	HeaderAge         = "Age"
+)
+
+// CacheStatus reports how the repository cache answered the request.
+// X-Cache is HIT, MISS or STALE, and stale responses also carry an Age header
+// with the age of the oldest stale content in seconds.
+func CacheStatus() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx, status := cache.WithStatus(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			c.Response().Before(func() {
+				result := status.Result()
+				if result == "" {
+					return
+				}
+
+				header := c.Response().Header()
+				header.Set(HeaderCacheStatus, result)
+				if result == cache.ResultStale {
+					header.Set(HeaderAge, strconv.Itoa(int(status.StaleAge().Seconds())))
+				}
+			})
+
+			return next(c)
+		}
+	}
+}
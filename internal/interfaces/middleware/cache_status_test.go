@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/infrastructure/cache"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCacheStatus(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+	mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, nil).Times(1)
+
+	opts := cache.Options{
+		StaleIfError: time.Hour,
+		TTL:          cache.TTL{ZennArticles: time.Minute},
+	}
+	repo := cache.NewZennRepository(mockRepo, cache.NewStore(10), opts)
+
+	e := echo.New()
+	e.Use(middleware.CacheStatus())
+	e.GET("/articles", func(c echo.Context) error {
+		if _, err := repo.GetArticles(c.Request().Context(), 10, 0, nil); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/plain", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "first request misses", path: "/articles", want: cache.ResultMiss},
+		{name: "second request hits", path: "/articles", want: cache.ResultHit},
+		{name: "request without cache access", path: "/plain", want: ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status code 200, got: %d", tt.name, rec.Code)
+		}
+		if got := rec.Header().Get(middleware.HeaderCacheStatus); got != tt.want {
+			t.Errorf("%s: expected X-Cache '%s', got: '%s'", tt.name, tt.want, got)
+		}
+		if got := rec.Header().Get(middleware.HeaderAge); got != "" {
+			t.Errorf("%s: expected no Age header, got: '%s'", tt.name, got)
+		}
+	}
+}
+
+func TestCacheStatus_Stale(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockArticleReader(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, nil),
+		mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, errors.New("zenn is down")),
+	)
+
+	opts := cache.Options{
+		StaleIfError: time.Hour,
+		TTL:          cache.TTL{ZennArticles: time.Millisecond},
+	}
+	repo := cache.NewZennRepository(mockRepo, cache.NewStore(10), opts)
+
+	if _, err := repo.GetArticles(context.Background(), 10, 0, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	e := echo.New()
+	e.Use(middleware.CacheStatus())
+	e.GET("/articles", func(c echo.Context) error {
+		if _, err := repo.GetArticles(c.Request().Context(), 10, 0, nil); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code 200, got: %d", rec.Code)
+	}
+	if got := rec.Header().Get(middleware.HeaderCacheStatus); got != cache.ResultStale {
+		t.Errorf("Expected X-Cache 'STALE', got: '%s'", got)
+	}
+	if got := rec.Header().Get(middleware.HeaderAge); got != "0" {
+		t.Errorf("Expected Age '0', got: '%s'", got)
+	}
+}
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfNoneMatch = "If-None-Match"
+
+	// updatedAtKey is the JSON key whose newest value becomes Last-Modified.
+	updatedAtKey = "UpdatedAt"
+)
+
+type ConditionalConfig struct {
+	Skipper echomiddleware.Skipper
+	// CacheControl maps route paths, as registered with echo, to the
+	// Cache-Control header sent with their successful responses.
+	CacheControl map[string]string
+}
+
+// Conditional buffers successful GET and HEAD responses to give them a strong
+// ETag and, for JSON containing articles, a Last-Modified taken from the newest
+// UpdatedAt. Requests whose If-None-Match or If-Modified-Since still match get
+// 304 Not Modified without a body.
+func Conditional(config ConditionalConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = echomiddleware.DefaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if config.Skipper(c) || (method != http.MethodGet && method != http.MethodHead) {
+				return next(c)
+			}
+
+			res := c.Response()
+			original := res.Writer
+			buf := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+			res.Writer = buf
+
+			err := next(c)
+			res.Writer = original
+
+			if !buf.wroteHeader {
+				// Nothing was written; the error handler renders the response.
+				return err
+			}
+			if buf.status != http.StatusOK {
+				return errors.Join(err, flush(res, buf.status, buf.body.Bytes()))
+			}
+
+			header := original.Header()
+			body := buf.body.Bytes()
+			etag := computeETag(body)
+			header.Set(HeaderETag, etag)
+
+			lastModified := newestUpdatedAt(header.Get(echo.HeaderContentType), body)
+			if !lastModified.IsZero() {
+				header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+			}
+			if cacheControl := config.CacheControl[c.Path()]; cacheControl != "" {
+				header.Set(echo.HeaderCacheControl, cacheControl)
+				// The body depends on the key's scopes, so shared caches must
+				// not serve it to requests carrying another key.
+				header.Add(echo.HeaderVary, HeaderAPIKey)
+			}
+
+			if notModified(c.Request(), etag, lastModified) {
+				header.Del(echo.HeaderContentType)
+				header.Del(echo.HeaderContentLength)
+				return errors.Join(err, flush(res, http.StatusNotModified, nil))
+			}
+
+			return errors.Join(err, flush(res, http.StatusOK, body))
+		}
+	}
+}
+
+// flush sends the final status and body on the restored writer. echo.Response
+// already counts as committed from the buffered write, so its Status and Size
+// are updated here to reflect what was actually sent.
+func flush(res *echo.Response, status int, body []byte) error {
+	res.Writer.WriteHeader(status)
+	res.Status = status
+	res.Size = 0
+	if len(body) == 0 {
+		return nil
+	}
+	n, err := res.Writer.Write(body)
+	res.Size = int64(n)
+	return err
+}
+
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+}
+
+// notModified evaluates the conditional request headers. If-Modified-Since is
+// only considered when If-None-Match is absent.
+func notModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if inm := req.Header.Get(HeaderIfNoneMatch); inm != "" {
+		return etagMatches(inm, etag)
+	}
+
+	ims := req.Header.Get(echo.HeaderIfModifiedSince)
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches uses the weak comparison required for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// newestUpdatedAt returns the newest UpdatedAt found anywhere in a JSON body,
+// or the zero time when there is none.
+func newestUpdatedAt(contentType string, body []byte) time.Time {
+	if !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		return time.Time{}
+	}
+
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return time.Time{}
+	}
+
+	var newest time.Time
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if s, ok := value.(string); ok && key == updatedAtKey {
+					if t, err := time.Parse(time.RFC3339Nano, s); err == nil && t.After(newest) {
+						newest = t
+					}
+					continue
+				}
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	walk(payload)
+
+	return newest
+}
+
+// bufferedWriter holds the status and body back so that the ETag can be
+// computed from the complete response before anything is sent.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = code
+	w.wroteHeader = true
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
+
+// Flush is a no-op because the response is only sent once complete.
+func (w *bufferedWriter) Flush() {}
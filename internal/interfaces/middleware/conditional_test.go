@@ -0,0 +1,195 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func newConditionalServer() *echo.Echo {
+	e := echo.New()
+	e.Use(middleware.CacheStatus())
+	e.Use(middleware.Conditional(middleware.ConditionalConfig{
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/health"
+		},
+		CacheControl: map[string]string{
+			"/articles": "public, max-age=60",
+		},
+	}))
+	e.GET("/articles", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]any{
+			"articles": []map[string]any{
+				{"ID": "1", "UpdatedAt": "2024-01-01T00:00:00Z"},
+				{"ID": "2", "UpdatedAt": "2024-03-01T12:30:00.5Z"},
+			},
+		})
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "not found"})
+	})
+	e.GET("/health", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return e
+}
+
+func TestConditional_SetsValidators(t *testing.T) {
+	t.Parallel()
+
+	e := newConditionalServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code 200, got: %d", rec.Code)
+	}
+	if rec.Header().Get(middleware.HeaderETag) == "" {
+		t.Error("Expected an ETag header")
+	}
+	if got := rec.Header().Get(echo.HeaderLastModified); got != "Fri, 01 Mar 2024 12:30:00 GMT" {
+		t.Errorf("Expected Last-Modified from the newest UpdatedAt, got: %s", got)
+	}
+	if got := rec.Header().Get(echo.HeaderCacheControl); got != "public, max-age=60" {
+		t.Errorf("Expected Cache-Control from config, got: %s", got)
+	}
+	if got := rec.Header().Get(echo.HeaderVary); got != middleware.HeaderAPIKey {
+		t.Errorf("Expected Vary: %s, got: %s", middleware.HeaderAPIKey, got)
+	}
+	if rec.Body.Len() == 0 {
+		t.Error("Expected the response body to be sent")
+	}
+}
+
+func TestConditional_NotModified(t *testing.T) {
+	t.Parallel()
+
+	e := newConditionalServer()
+
+	first := httptest.NewRecorder()
+	e.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/articles", nil))
+	etag := first.Header().Get(middleware.HeaderETag)
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{name: "matching etag", header: middleware.HeaderIfNoneMatch, value: etag, want: http.StatusNotModified},
+		{name: "weak matching etag", header: middleware.HeaderIfNoneMatch, value: `"other", W/` + etag, want: http.StatusNotModified},
+		{name: "wildcard etag", header: middleware.HeaderIfNoneMatch, value: "*", want: http.StatusNotModified},
+		{name: "different etag", header: middleware.HeaderIfNoneMatch, value: `"other"`, want: http.StatusOK},
+		{name: "not modified since", header: echo.HeaderIfModifiedSince, value: "Fri, 01 Mar 2024 12:30:00 GMT", want: http.StatusNotModified},
+		{name: "modified since", header: echo.HeaderIfModifiedSince, value: "Thu, 29 Feb 2024 00:00:00 GMT", want: http.StatusOK},
+		{name: "invalid date", header: echo.HeaderIfModifiedSince, value: "yesterday", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+		req.Header.Set(tt.header, tt.value)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected status code %d, got: %d", tt.name, tt.want, rec.Code)
+		}
+		if rec.Code == http.StatusNotModified {
+			if rec.Body.Len() != 0 {
+				t.Errorf("%s: expected an empty body, got: %q", tt.name, rec.Body.String())
+			}
+			if rec.Header().Get(middleware.HeaderETag) != etag {
+				t.Errorf("%s: expected the ETag on the 304 response", tt.name)
+			}
+		}
+	}
+}
+
+func TestConditional_IfNoneMatchTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	e := newConditionalServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set(middleware.HeaderIfNoneMatch, `"other"`)
+	req.Header.Set(echo.HeaderIfModifiedSince, time.Now().UTC().Format(http.TimeFormat))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status code 200, got: %d", rec.Code)
+	}
+}
+
+func TestConditional_RecordsSentResponse(t *testing.T) {
+	t.Parallel()
+
+	var status int
+	var size int64
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			status, size = c.Response().Status, c.Response().Size
+			return err
+		}
+	})
+	e.Use(middleware.Conditional(middleware.ConditionalConfig{}))
+	e.GET("/articles", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"ID": "1"})
+	})
+
+	first := httptest.NewRecorder()
+	e.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/articles", nil))
+
+	if status != http.StatusOK || size != int64(first.Body.Len()) {
+		t.Errorf("Expected 200 with size %d, got: %d with size %d", first.Body.Len(), status, size)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set(middleware.HeaderIfNoneMatch, first.Header().Get(middleware.HeaderETag))
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	if status != http.StatusNotModified {
+		t.Errorf("Expected c.Response().Status 304, got: %d", status)
+	}
+	if size != 0 {
+		t.Errorf("Expected c.Response().Size 0, got: %d", size)
+	}
+}
+
+func TestConditional_PassesThroughOtherResponses(t *testing.T) {
+	t.Parallel()
+
+	e := newConditionalServer()
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "error response", path: "/missing", want: http.StatusNotFound},
+		{name: "skipped route", path: "/health", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected status code %d, got: %d", tt.name, tt.want, rec.Code)
+		}
+		if rec.Header().Get(middleware.HeaderETag) != "" {
+			t.Errorf("%s: expected no ETag header", tt.name)
+		}
+		if rec.Body.Len() == 0 {
+			t.Errorf("%s: expected the response body to be sent", tt.name)
+		}
+	}
+}
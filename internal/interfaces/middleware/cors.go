@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
+)
+
+// CORSConfig defines the CORS policy.
+type CORSConfig struct {
+	// AllowOrigins lists the origins browsers may call the API from. An entry
+	// is "*" for any origin, or scheme://host[:port] where the host may start
+	// with "*." to allow its subdomains and the port may be "*".
+	AllowOrigins     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// CORS answers preflight requests and adds the CORS headers for the allowed
+// origins. Preflights from other origins get no CORS headers, so browsers
+// block the request, and are logged.
+func CORS(config CORSConfig) echo.MiddlewareFunc {
+	origins := make([]originPattern, 0, len(config.AllowOrigins))
+	for _, o := range config.AllowOrigins {
+		if p, ok := parseOriginPattern(o); ok {
+			origins = append(origins, p)
+		}
+	}
+	allowed := func(origin string) bool {
+		for _, p := range origins {
+			if p.match(origin) {
+				return true
+			}
+		}
+		return false
+	}
+
+	cors := echomiddleware.CORSWithConfig(echomiddleware.CORSConfig{
+		AllowOriginFunc: func(origin string) (bool, error) {
+			return allowed(origin), nil
+		},
+		AllowHeaders:     config.AllowHeaders,
+		ExposeHeaders:    config.ExposeHeaders,
+		AllowCredentials: config.AllowCredentials,
+		MaxAge:           int(config.MaxAge.Seconds()),
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		handler := cors(next)
+		return func(c echo.Context) error {
+			req := c.Request()
+			origin := req.Header.Get(echo.HeaderOrigin)
+			if req.Method == http.MethodOptions && origin != "" &&
+				req.Header.Get(echo.HeaderAccessControlRequestMethod) != "" && !allowed(origin) {
+				logger.FromContext(req.Context()).Warn("Rejected CORS preflight",
+					zap.String("origin", origin),
+					zap.String("path", req.URL.Path),
+					zap.String("request_method", req.Header.Get(echo.HeaderAccessControlRequestMethod)),
+				)
+			}
+			return handler(c)
+		}
+	}
+}
+
+// originPattern is a parsed AllowOrigins entry.
+type originPattern struct {
+	any        bool
+	scheme     string
+	host       string
+	subdomains bool
+	// port is empty for the default port of the scheme and "*" for any port,
+	// including the default one.
+	port string
+}
+
+func parseOriginPattern(s string) (originPattern, bool) {
+	if s == "*" {
+		return originPattern{any: true}, true
+	}
+	p, ok := splitOrigin(s)
+	if !ok {
+		return originPattern{}, false
+	}
+	if host, found := strings.CutPrefix(p.host, "*."); found {
+		p.host = host
+		p.subdomains = true
+	}
+	return p, p.host != ""
+}
+
+func (p originPattern) match(origin string) bool {
+	if p.any {
+		return true
+	}
+	o, ok := splitOrigin(origin)
+	if !ok || o.scheme != p.scheme || (p.port != "*" && o.port != p.port) {
+		return false
+	}
+	if p.subdomains {
+		return strings.HasSuffix(o.host, "."+p.host)
+	}
+	return o.host == p.host
+}
+
+// splitOrigin splits scheme://host[:port], rejecting anything with a path.
+func splitOrigin(s string) (originPattern, bool) {
+	scheme, rest, ok := strings.Cut(strings.ToLower(s), "://")
+	if !ok || scheme == "" || rest == "" || strings.ContainsAny(rest, "/?#@") {
+		return originPattern{}, false
+	}
+	host, port, found := strings.Cut(rest, ":")
+	if found && port == "" {
+		return originPattern{}, false
+	}
+	return originPattern{scheme: scheme, host: host, port: port}, true
+}
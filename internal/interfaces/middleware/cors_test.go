@@ -0,0 +1,140 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newCORSServer(config middleware.CORSConfig) (*echo.Echo, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(logger.NewContext(c.Request().Context(), zap.New(core))))
+			return next(c)
+		}
+	})
+	e.Use(middleware.CORS(config))
+	e.GET("/articles", func(c echo.Context) error {
+		return c.String(http.StatusOK, "articles")
+	})
+	return e, logs
+}
+
+func TestCORS_Origins(t *testing.T) {
+	t.Parallel()
+
+	e, _ := newCORSServer(middleware.CORSConfig{
+		AllowOrigins: []string{
+			"https://example.com",
+			"https://*.example.net",
+			"http://localhost:*",
+		},
+	})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "https://example.com", allowed: true},
+		{origin: "HTTPS://EXAMPLE.COM", allowed: true},
+		{origin: "http://example.com", allowed: false},
+		{origin: "https://example.com:8443", allowed: false},
+		{origin: "https://www.example.com", allowed: false},
+		{origin: "https://preview.example.net", allowed: true},
+		{origin: "https://a.b.example.net", allowed: true},
+		{origin: "https://example.net", allowed: false},
+		{origin: "https://evilexample.net", allowed: false},
+		{origin: "https://example.net.evil.com", allowed: false},
+		{origin: "http://localhost:3000", allowed: true},
+		{origin: "http://localhost", allowed: true},
+		{origin: "null", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+			req.Header.Set(echo.HeaderOrigin, tt.origin)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			if tt.allowed {
+				assert.Equal(t, tt.origin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			} else {
+				assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			}
+		})
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	t.Parallel()
+
+	e, logs := newCORSServer(middleware.CORSConfig{
+		AllowOrigins:     []string{"https://example.com"},
+		AllowHeaders:     []string{echo.HeaderContentType, middleware.HeaderAPIKey},
+		ExposeHeaders:    []string{middleware.HeaderRateLimitRemaining},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/articles", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+		req.Header.Set(echo.HeaderAccessControlRequestHeaders, "x-api-key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := preflight("https://example.com")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "Content-Type,X-API-Key", rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+	assert.Zero(t, logs.Len())
+
+	rec = preflight("https://evil.example")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	rejected := logs.FilterMessage("Rejected CORS preflight").All()
+	if assert.Len(t, rejected, 1) {
+		assert.Equal(t, "https://evil.example", rejected[0].ContextMap()["origin"])
+		assert.Equal(t, "/articles", rejected[0].ContextMap()["path"])
+	}
+
+	// Simple requests expose the configured headers
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set(echo.HeaderOrigin, "https://example.com")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, middleware.HeaderRateLimitRemaining, rec.Header().Get(echo.HeaderAccessControlExposeHeaders))
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	t.Parallel()
+
+	e, _ := newCORSServer(middleware.CORSConfig{AllowOrigins: []string{"*"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/articles", nil)
+	req.Header.Set(echo.HeaderOrigin, "https://anywhere.example")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, "https://anywhere.example", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+}
@@ -0,0 +1,8 @@
+package middleware
+
+import "time"
+
+// Export private fields for testing
+func (r *KeyRing) SetNow(now func() time.Time) {
+	r.now = now
+}
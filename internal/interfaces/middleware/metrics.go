@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests that matched no route, so that arbitrary
+// paths do not create new series.
+const unmatchedRoute = "unmatched"
+
+// authFailureReasons labels the errors returned by the authentication
+// middleware.
+var authFailureReasons = []struct {
+	err    error
+	reason string
+}{
+	{err: errMissingAPIKey, reason: "missing_api_key"},
+	{err: errInvalidAPIKey, reason: "invalid_api_key"},
+	{err: errExpiredAPIKey, reason: "expired_api_key"},
+	{err: errInsufficientScope, reason: "insufficient_scope"},
+	{err: errMissingSignature, reason: "missing_signature"},
+	{err: errInvalidSignature, reason: "invalid_signature"},
+	{err: errMissingPreviewToken, reason: "missing_preview_token"},
+	{err: errInvalidPreviewToken, reason: "invalid_preview_token"},
+	{err: errExpiredPreviewToken, reason: "expired_preview_token"},
+}
+
+// Metrics records the count and latency of every request by method, route
+// template and status, and the requests of each API key. Like AccessLog, it
+// renders errors returned by the handler so that the recorded status is the
+// one sent to the client.
+func Metrics(m *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" || route == "/*" {
+				route = unmatchedRoute
+			}
+			m.ObserveHTTPRequest(c.Request().Method, route, c.Response().Status, time.Since(start))
+			if name := APIKeyName(c); name != "" {
+				m.ObserveAPIKeyRequest(name, c.Response().Status)
+			}
+			return nil
+		}
+	}
+}
+
+// CountAuthFailures wraps the authentication middleware auth and counts the
+// requests it rejects under scheme.
+func CountAuthFailures(m *metrics.Metrics, scheme string, auth echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authenticated := auth(next)
+		return func(c echo.Context) error {
+			err := authenticated(c)
+			for _, r := range authFailureReasons {
+				if errors.Is(err, r.err) {
+					m.AuthFailure(scheme, r.reason)
+					break
+				}
+			}
+			return err
+		}
+	}
+}
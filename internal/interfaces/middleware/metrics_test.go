@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/metrics"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func TestMetrics_RecordsRequests(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+	e := echo.New()
+	e.Use(middleware.Metrics(m))
+	ring := middleware.NewKeyRing([]middleware.APIKey{
+		{Name: "web", SHA256: middleware.HashAPIKey("valid-api-key"), Scopes: []middleware.Scope{middleware.ScopeRead}},
+		{Name: "admin", SHA256: middleware.HashAPIKey("admin-api-key"), Scopes: []middleware.Scope{middleware.ScopeAdmin}},
+	})
+	e.Use(middleware.CountAuthFailures(m, "api_key", middleware.APIKeyAuth(ring, middleware.ScopeRead)))
+	e.GET("/articles/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "article")
+	})
+	e.GET("/broken", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+
+	requests := []struct {
+		path   string
+		apiKey string
+		want   int
+	}{
+		{path: "/articles/1", apiKey: "valid-api-key", want: http.StatusOK},
+		{path: "/articles/2", apiKey: "valid-api-key", want: http.StatusOK},
+		{path: "/broken", apiKey: "valid-api-key", want: http.StatusInternalServerError},
+		{path: "/articles/1", apiKey: "", want: http.StatusUnauthorized},
+		{path: "/articles/1", apiKey: "wrong", want: http.StatusUnauthorized},
+		{path: "/articles/1", apiKey: "admin-api-key", want: http.StatusForbidden},
+		{path: "/no/such/route", apiKey: "valid-api-key", want: http.StatusNotFound},
+	}
+	for _, r := range requests {
+		req := httptest.NewRequest(http.MethodGet, r.path, nil)
+		req.Header.Set("X-API-Key", r.apiKey)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != r.want {
+			t.Errorf("%s: expected status code %d, got: %d", r.path, r.want, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, line := range []string{
+		`nerine_http_requests_total{method="GET",route="/articles/:id",status="200"} 2`,
+		`nerine_http_requests_total{method="GET",route="/articles/:id",status="401"} 2`,
+		`nerine_http_requests_total{method="GET",route="/broken",status="500"} 1`,
+		`nerine_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`nerine_auth_failures_total{reason="missing_api_key",scheme="api_key"} 1`,
+		`nerine_auth_failures_total{reason="invalid_api_key",scheme="api_key"} 1`,
+		`nerine_auth_failures_total{reason="insufficient_scope",scheme="api_key"} 1`,
+		`nerine_api_key_requests_total{api_key="web",status="200"} 2`,
+		`nerine_api_key_requests_total{api_key="web",status="500"} 1`,
+		`nerine_api_key_requests_total{api_key="admin",status="403"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected %q in the metrics:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, "/no/such/route") {
+		t.Error("Expected unmatched paths not to become route labels")
+	}
+}
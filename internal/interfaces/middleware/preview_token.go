@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/infrastructure/preview"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	// QueryPreviewToken is the query parameter carrying a preview token.
+	QueryPreviewToken = "token"
+
+	// previewTokenKey is the echo context key under which PreviewToken
+	// stores the verified token.
+	previewTokenKey = "previewToken"
+)
+
+// Errors returned when the preview token is rejected.
+var (
+	errMissingPreviewToken = echo.NewHTTPError(http.StatusUnauthorized, "missing preview token")
+	errInvalidPreviewToken = echo.NewHTTPError(http.StatusUnauthorized, "invalid preview token")
+	errExpiredPreviewToken = echo.NewHTTPError(http.StatusUnauthorized, "expired preview token")
+)
+
+// PreviewTokenFrom returns the token verified by PreviewToken.
+func PreviewTokenFrom(c echo.Context) (preview.Token, bool) {
+	token, ok := c.Get(previewTokenKey).(preview.Token)
+	return token, ok
+}
+
+// PreviewToken accepts requests whose token query parameter is a valid,
+// unexpired token of sealer for the article in the id path parameter. It
+// authenticates reviewers who hold a preview link but no API key.
+func PreviewToken(sealer *preview.Sealer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			raw := c.QueryParam(QueryPreviewToken)
+			if raw == "" {
+				return errMissingPreviewToken
+			}
+
+			token, err := sealer.Open(raw)
+			switch {
+			case errors.Is(err, preview.ErrExpiredToken):
+				return errExpiredPreviewToken
+			case err != nil:
+				return errInvalidPreviewToken
+			case token.ArticleID != c.Param("id"):
+				// A token only opens the article it was issued for.
+				return errInvalidPreviewToken
+			}
+
+			c.Set(previewTokenKey, token)
+			ctx := c.Request().Context()
+			requestLogger := logger.FromContext(ctx).With(zap.String("article_id", token.ArticleID))
+			c.SetRequest(c.Request().WithContext(logger.NewContext(ctx, requestLogger)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/preview"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func TestPreviewToken(t *testing.T) {
+	t.Parallel()
+
+	sealer := preview.NewSealer("0123456789abcdef0123456789abcdef")
+
+	valid, _, err := sealer.Seal("article-1", "draft-key", time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expired, _, err := sealer.Seal("article-1", "draft-key", -time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		articleID      string
+		token          string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "valid token", articleID: "article-1", token: valid, expectedStatus: http.StatusOK},
+		{name: "missing token", articleID: "article-1", token: "", expectedStatus: http.StatusUnauthorized, expectedError: "missing preview token"},
+		{name: "tampered token", articleID: "article-1", token: valid + "x", expectedStatus: http.StatusUnauthorized, expectedError: "invalid preview token"},
+		{name: "token of another article", articleID: "article-2", token: valid, expectedStatus: http.StatusUnauthorized, expectedError: "invalid preview token"},
+		{name: "expired token", articleID: "article-1", token: expired, expectedStatus: http.StatusUnauthorized, expectedError: "expired preview token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/preview/articles/"+tt.articleID+"?token="+tt.token, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.articleID)
+
+			var received preview.Token
+			handler := middleware.PreviewToken(sealer)(func(c echo.Context) error {
+				received, _ = middleware.PreviewTokenFrom(c)
+				return c.NoContent(http.StatusOK)
+			})
+
+			err := handler(c)
+
+			if tt.expectedStatus == http.StatusOK {
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				if received.ArticleID != "article-1" || received.DraftKey != "draft-key" {
+					t.Errorf("Expected the verified token in the context, got: %+v", received)
+				}
+				return
+			}
+
+			he, ok := err.(*echo.HTTPError)
+			if !ok {
+				t.Fatalf("Expected HTTPError, got: %T", err)
+			}
+			if he.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got: %d", tt.expectedStatus, he.Code)
+			}
+			if he.Message != tt.expectedError {
+				t.Errorf("Expected error message %q, got: %v", tt.expectedError, he.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/logger"
+	"github.com/kozennoki/nerine/internal/infrastructure/ratelimit"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+var errRateLimited = echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+
+type RateLimitConfig struct {
+	Skipper echomiddleware.Skipper
+	Store   ratelimit.Store
+	// Key is the limit of each API key, per scope. Scopes overrides it for
+	// a scope and Keys for a key name.
+	Key    ratelimit.Limit
+	Scopes map[Scope]ratelimit.Limit
+	Keys   map[string]ratelimit.Limit
+	// IP is the limit of each client IP on routes without an API key.
+	IP ratelimit.Limit
+}
+
+// RateLimit limits requests per API key and, on routes without one, per
+// client IP. It must run after APIKeyAuth. Limited responses carry the
+// RateLimit-* headers, and rejected ones get 429 with Retry-After.
+//
+// When the store fails the request is let through, so that an outage of a
+// shared store does not take the API down with it.
+func RateLimit(config RateLimitConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = echomiddleware.DefaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			key, limit := config.limitOf(c)
+			if limit.Unlimited() {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			result, err := config.Store.Take(ctx, key, limit)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Rate limit store failed, allowing the request", zap.Error(err))
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+			if !result.Allowed {
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				return errRateLimited
+			}
+			return next(c)
+		}
+	}
+}
+
+// LimitAuthFailures puts the requests that present no key of ring, which auth
+// is about to reject, through RateLimit before auth runs. They are limited
+// per client IP with config.IP, so that API keys cannot be guessed faster
+// than that, while requests with a known key are left to auth and the
+// per-key limits.
+func LimitAuthFailures(config RateLimitConfig, ring *KeyRing, auth echo.MiddlewareFunc) echo.MiddlewareFunc {
+	limit := RateLimit(config)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authenticated := auth(next)
+		limited := limit(authenticated)
+		return func(c echo.Context) error {
+			if _, ok := ring.lookup(c.Request().Header.Get(HeaderAPIKey)); ok {
+				return authenticated(c)
+			}
+			return limited(c)
+		}
+	}
+}
+
+// limitOf returns the bucket key and limit of the client of c.
+func (config RateLimitConfig) limitOf(c echo.Context) (string, ratelimit.Limit) {
+	name := APIKeyName(c)
+	if name == "" {
+		return "ip:" + c.RealIP(), config.IP
+	}
+
+	scope := APIKeyScope(c)
+	key := "key:" + name + ":" + string(scope)
+	if limit, ok := config.Keys[name]; ok {
+		return key, limit
+	}
+	if limit, ok := config.Scopes[scope]; ok {
+		return key, limit
+	}
+	return key, config.Key
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,181 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/infrastructure/ratelimit"
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a rate limit store that is down.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func newRateLimitServer(store ratelimit.Store) *echo.Echo {
+	ring := middleware.NewKeyRing([]middleware.APIKey{
+		{Name: "web", SHA256: middleware.HashAPIKey("web-key"), Scopes: []middleware.Scope{middleware.ScopeRead}},
+		{Name: "batch", SHA256: middleware.HashAPIKey("batch-key"), Scopes: []middleware.Scope{middleware.ScopeRead}},
+		{Name: "ops", SHA256: middleware.HashAPIKey("ops-key"), Scopes: []middleware.Scope{middleware.ScopeAdmin}},
+	})
+
+	config := middleware.RateLimitConfig{
+		Store:  store,
+		Key:    ratelimit.Limit{Requests: 2, Period: time.Minute},
+		Scopes: map[middleware.Scope]ratelimit.Limit{middleware.ScopeAdmin: {}},
+		Keys:   map[string]ratelimit.Limit{"batch": {Requests: 5, Period: time.Minute}},
+		IP:     ratelimit.Limit{Requests: 1, Period: time.Minute},
+	}
+	readAuth := middleware.LimitAuthFailures(config, ring, middleware.APIKeyAuth(ring, middleware.ScopeRead))
+	adminAuth := middleware.LimitAuthFailures(config, ring, middleware.APIKeyAuth(ring, middleware.ScopeAdmin))
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			switch c.Path() {
+			case "/webhook":
+				return next(c)
+			case "/admin":
+				return adminAuth(next)(c)
+			default:
+				return readAuth(next)(c)
+			}
+		}
+	})
+	e.Use(middleware.RateLimit(config))
+	for _, path := range []string{"/articles", "/admin", "/webhook"} {
+		e.GET(path, func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+	}
+	return e
+}
+
+func serveRateLimited(e *echo.Echo, path, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if apiKey != "" {
+		req.Header.Set(middleware.HeaderAPIKey, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		apiKey     string
+		requests   int
+		wantLimit  string
+		wantLastOK bool
+	}{
+		{name: "default key limit", path: "/articles", apiKey: "web-key", requests: 3, wantLimit: "2"},
+		{name: "per key limit", path: "/articles", apiKey: "batch-key", requests: 5, wantLimit: "5", wantLastOK: true},
+		{name: "unlimited scope", path: "/admin", apiKey: "ops-key", requests: 10, wantLastOK: true},
+		{name: "IP without an API key", path: "/webhook", requests: 2, wantLimit: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := newRateLimitServer(ratelimit.NewMemoryStore())
+
+			var rec *httptest.ResponseRecorder
+			for i := 0; i < tt.requests; i++ {
+				rec = serveRateLimited(e, tt.path, tt.apiKey)
+			}
+
+			assert.Equal(t, tt.wantLimit, rec.Header().Get(middleware.HeaderRateLimitLimit))
+			if tt.wantLastOK {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+				return
+			}
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.Equal(t, "0", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+			assert.Equal(t, "60", rec.Header().Get(middleware.HeaderRateLimitReset))
+			assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+		})
+	}
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	t.Parallel()
+
+	e := newRateLimitServer(ratelimit.NewMemoryStore())
+
+	rec := serveRateLimited(e, "/articles", "web-key")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(middleware.HeaderRateLimitLimit))
+	assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", rec.Header().Get(middleware.HeaderRateLimitReset))
+
+	rec = serveRateLimited(e, "/articles", "web-key")
+	rec = serveRateLimited(e, "/articles", "web-key")
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+
+	// Keys do not share buckets
+	rec = serveRateLimited(e, "/articles", "batch-key")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestLimitAuthFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		apiKey string
+	}{
+		{name: "invalid API key", apiKey: "guessed-key"},
+		{name: "missing API key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := newRateLimitServer(ratelimit.NewMemoryStore())
+
+			rec := serveRateLimited(e, "/articles", tt.apiKey)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRateLimitLimit))
+
+			rec = serveRateLimited(e, "/articles", tt.apiKey)
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+
+			// Known keys from the same client IP keep their own limits
+			rec = serveRateLimited(e, "/articles", "web-key")
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "2", rec.Header().Get(middleware.HeaderRateLimitLimit))
+		})
+	}
+}
+
+func TestRateLimit_StoreFailureAllowsRequests(t *testing.T) {
+	t.Parallel()
+
+	e := newRateLimitServer(failingStore{})
+
+	for i := 0; i < 3; i++ {
+		rec := serveRateLimited(e, "/articles", "web-key")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(middleware.HeaderRateLimitLimit))
+	}
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/interfaces/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestMicroCMSSignature(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secret = "webhook-secret"
+		body   = `{"api":"blog","id":"article-1","type":"edit"}`
+	)
+
+	tests := []struct {
+		name           string
+		signature      string
+		expectedStatus int
+	}{
+		{name: "valid signature", signature: sign(secret, body), expectedStatus: http.StatusOK},
+		{name: "missing signature", signature: "", expectedStatus: http.StatusUnauthorized},
+		{name: "malformed signature", signature: "not-hex", expectedStatus: http.StatusUnauthorized},
+		{name: "wrong secret", signature: sign("other-secret", body), expectedStatus: http.StatusUnauthorized},
+		{name: "signature of another body", signature: sign(secret, body+" "), expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/microcms", strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(middleware.HeaderMicroCMSSignature, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var received string
+			handler := middleware.MicroCMSSignature(secret)(func(c echo.Context) error {
+				b, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return err
+				}
+				received = string(b)
+				return c.NoContent(http.StatusOK)
+			})
+
+			err := handler(c)
+
+			if tt.expectedStatus == http.StatusOK {
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				if received != body {
+					t.Errorf("Expected handler to receive the body, got: %q", received)
+				}
+				return
+			}
+
+			he, ok := err.(*echo.HTTPError)
+			if !ok {
+				t.Fatalf("Expected HTTPError, got: %T", err)
+			}
+			if he.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got: %d", tt.expectedStatus, he.Code)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package presenter
 
 import (
+	"time"
+
 	"github.com/kozennoki/nerine/internal/domain/entity"
 	"github.com/kozennoki/nerine/internal/infrastructure/utils"
 	"github.com/kozennoki/nerine/internal/openapi"
@@ -28,6 +30,66 @@ func ConvertArticles(articles []*entity.Article) []openapi.Article {
 	return result
 }
 
+// PartialArticle is an openapi.Article that leaves unselected fields out of
+// the JSON. ID is always present.
+type PartialArticle struct {
+	ID          string            `json:"ID"`
+	Title       *string           `json:"Title,omitempty"`
+	Image       *string           `json:"Image,omitempty"`
+	Category    *openapi.Category `json:"Category,omitempty"`
+	Description *string           `json:"Description,omitempty"`
+	Body        *string           `json:"Body,omitempty"`
+	PublishedAt *time.Time        `json:"PublishedAt,omitempty"`
+	CreatedAt   *time.Time        `json:"CreatedAt,omitempty"`
+	UpdatedAt   *time.Time        `json:"UpdatedAt,omitempty"`
+}
+
+// PartialArticlesResponse is openapi.ArticlesResponse with partial articles.
+type PartialArticlesResponse struct {
+	Articles   []PartialArticle    `json:"articles"`
+	Pagination *openapi.Pagination `json:"pagination,omitempty"`
+}
+
+// ConvertPartialArticle converts article keeping only the selected fields.
+// An empty selection keeps every field.
+func ConvertPartialArticle(article *entity.Article, fields []entity.ArticleField) PartialArticle {
+	result := PartialArticle{ID: article.ID}
+	if entity.HasArticleField(fields, entity.ArticleFieldTitle) {
+		result.Title = &article.Title
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldImage) {
+		result.Image = &article.Image
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldCategory) {
+		category := ConvertCategory(article.Category)
+		result.Category = &category
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldDescription) {
+		result.Description = &article.Description
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldBody) {
+		result.Body = &article.Body
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldPublishedAt) {
+		result.PublishedAt = &article.PublishedAt
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldCreatedAt) {
+		result.CreatedAt = &article.CreatedAt
+	}
+	if entity.HasArticleField(fields, entity.ArticleFieldUpdatedAt) {
+		result.UpdatedAt = &article.UpdatedAt
+	}
+	return result
+}
+
+func ConvertPartialArticles(articles []*entity.Article, fields []entity.ArticleField) []PartialArticle {
+	result := make([]PartialArticle, len(articles))
+	for i, article := range articles {
+		result[i] = ConvertPartialArticle(article, fields)
+	}
+	return result
+}
+
 func ConvertCategory(category entity.Category) openapi.Category {
 	return openapi.Category{
 		Slug: category.Slug,
@@ -56,7 +118,3 @@ func ConvertPagination(pagination utils.Pagination) *openapi.Pagination {
 		TotalPages: &totalPages,
 	}
 }
-
-func ConvertErrorMessage(err error) string {
-	return err.Error()
-}
@@ -1,7 +1,6 @@
 package presenter_test
 
 import (
-	"errors"
 	"testing"
 	"time"
 
@@ -119,6 +118,52 @@ func TestConvertArticles_EmptySlice(t *testing.T) {
 	}
 }
 
+func TestConvertPartialArticle(t *testing.T) {
+	t.Parallel()
+
+	article := &entity.Article{
+		ID:       "test-id",
+		Title:    "Test Title",
+		Image:    "example.png",
+		Category: entity.Category{Slug: "tech", Name: "Technology"},
+		Body:     "Test Body",
+	}
+
+	result := presenter.ConvertPartialArticle(article, []entity.ArticleField{
+		entity.ArticleFieldID,
+		entity.ArticleFieldTitle,
+		entity.ArticleFieldCategory,
+	})
+
+	if result.ID != "test-id" {
+		t.Errorf("ConvertPartialArticle().ID = %s, want test-id", result.ID)
+	}
+	if result.Title == nil || *result.Title != "Test Title" {
+		t.Errorf("ConvertPartialArticle().Title = %v, want Test Title", result.Title)
+	}
+	if result.Category == nil || result.Category.Slug != "tech" {
+		t.Errorf("ConvertPartialArticle().Category = %v, want tech", result.Category)
+	}
+	if result.Image != nil || result.Body != nil || result.PublishedAt != nil {
+		t.Errorf("ConvertPartialArticle() kept unselected fields: %+v", result)
+	}
+}
+
+func TestConvertPartialArticle_EmptySelection(t *testing.T) {
+	t.Parallel()
+
+	article := &entity.Article{ID: "test-id", Body: "Test Body"}
+
+	result := presenter.ConvertPartialArticle(article, nil)
+
+	if result.Body == nil || *result.Body != "Test Body" {
+		t.Errorf("ConvertPartialArticle().Body = %v, want Test Body", result.Body)
+	}
+	if result.UpdatedAt == nil {
+		t.Error("ConvertPartialArticle().UpdatedAt = nil, want every field with an empty selection")
+	}
+}
+
 func TestConvertCategory(t *testing.T) {
 	t.Parallel()
 
@@ -207,15 +252,3 @@ func TestConvertPagination(t *testing.T) {
 		t.Errorf("ConvertPagination().TotalPages = %v, want 10", result.TotalPages)
 	}
 }
-
-func TestConvertErrorMessage(t *testing.T) {
-	t.Parallel()
-
-	err := errors.New("test error message")
-	result := presenter.ConvertErrorMessage(err)
-
-	expected := "test error message"
-	if result != expected {
-		t.Errorf("ConvertErrorMessage() = %s, want %s", result, expected)
-	}
-}
@@ -0,0 +1,65 @@
+package presenter
+
+import "net/http"
+
+// ErrorCode is a stable, machine-readable identifier of an error response.
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidRequest      ErrorCode = "INVALID_REQUEST"
+	ErrorCodeUnauthorized        ErrorCode = "UNAUTHORIZED"
+	ErrorCodeNotFound            ErrorCode = "NOT_FOUND"
+	ErrorCodePayloadTooLarge     ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorCodeRateLimited         ErrorCode = "RATE_LIMITED"
+	ErrorCodeUpstreamUnavailable ErrorCode = "UPSTREAM_UNAVAILABLE"
+	ErrorCodeInternal            ErrorCode = "INTERNAL_ERROR"
+)
+
+// ErrorResponse extends openapi.ErrorResponse with an error code and the ID
+// of the request, which clients can quote when reporting a problem.
+type ErrorResponse struct {
+	Error     string    `json:"error"`
+	Detail    *string   `json:"detail,omitempty"`
+	Code      ErrorCode `json:"code"`
+	RequestID string    `json:"requestId,omitempty"`
+}
+
+var statusErrorCodes = map[int]ErrorCode{
+	http.StatusBadRequest:            ErrorCodeInvalidRequest,
+	http.StatusUnauthorized:          ErrorCodeUnauthorized,
+	http.StatusNotFound:              ErrorCodeNotFound,
+	http.StatusRequestEntityTooLarge: ErrorCodePayloadTooLarge,
+	http.StatusTooManyRequests:       ErrorCodeRateLimited,
+	http.StatusServiceUnavailable:    ErrorCodeUpstreamUnavailable,
+}
+
+// ConvertErrorCode returns the error code sent with status. Statuses without
+// a dedicated code are reported as invalid requests or internal errors.
+func ConvertErrorCode(status int) ErrorCode {
+	if code, ok := statusErrorCodes[status]; ok {
+		return code
+	}
+	if status < http.StatusInternalServerError {
+		return ErrorCodeInvalidRequest
+	}
+	return ErrorCodeInternal
+}
+
+// Problem is an RFC 7807 problem details document, extended with the error
+// code, the request ID and the parameters that failed validation.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	Code          ErrorCode      `json:"code"`
+	RequestID     string         `json:"requestId,omitempty"`
+	InvalidParams []InvalidParam `json:"invalidParams,omitempty"`
+}
+
+// InvalidParam describes why a request parameter was rejected.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
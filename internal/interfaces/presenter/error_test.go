@@ -0,0 +1,33 @@
+package presenter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/interfaces/presenter"
+)
+
+func TestConvertErrorCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		want   presenter.ErrorCode
+	}{
+		{status: http.StatusBadRequest, want: presenter.ErrorCodeInvalidRequest},
+		{status: http.StatusUnauthorized, want: presenter.ErrorCodeUnauthorized},
+		{status: http.StatusNotFound, want: presenter.ErrorCodeNotFound},
+		{status: http.StatusRequestEntityTooLarge, want: presenter.ErrorCodePayloadTooLarge},
+		{status: http.StatusTooManyRequests, want: presenter.ErrorCodeRateLimited},
+		{status: http.StatusServiceUnavailable, want: presenter.ErrorCodeUpstreamUnavailable},
+		{status: http.StatusMethodNotAllowed, want: presenter.ErrorCodeInvalidRequest},
+		{status: http.StatusInternalServerError, want: presenter.ErrorCodeInternal},
+		{status: http.StatusBadGateway, want: presenter.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		if got := presenter.ConvertErrorCode(tt.status); got != tt.want {
+			t.Errorf("ConvertErrorCode(%d) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package presenter
+
+import "github.com/kozennoki/nerine/internal/domain/entity"
+
+// HealthResponse is openapi.HealthResponse with the circuit breaker state of
+// each upstream.
+type HealthResponse struct {
+	Status    string            `json:"status"`
+	Upstreams map[string]string `json:"upstreams,omitempty"`
+}
+
+func ConvertUpstreamStatuses(upstreams []*entity.UpstreamStatus) map[string]string {
+	if len(upstreams) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(upstreams))
+	for _, upstream := range upstreams {
+		result[upstream.Name] = string(upstream.State)
+	}
+	return result
+}
@@ -0,0 +1,7 @@
+package presenter
+
+// LogLevelResponse is the body of the log level endpoint. The same shape is
+// accepted to change the level.
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
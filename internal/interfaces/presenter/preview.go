@@ -0,0 +1,16 @@
+package presenter
+
+import "time"
+
+// PreviewTokenRequest asks for a preview link to the draft of an article.
+// ExpiresIn is in seconds; zero uses the configured default.
+type PreviewTokenRequest struct {
+	ArticleID string `json:"articleId"`
+	DraftKey  string `json:"draftKey"`
+	ExpiresIn int    `json:"expiresIn"`
+}
+
+type PreviewTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,32 @@
+package presenter
+
+import "time"
+
+// ReadinessResponse is the body of /ready. Dependencies are keyed by name.
+type ReadinessResponse struct {
+	Status       string                        `json:"status"`
+	Dependencies map[string]DependencyResponse `json:"dependencies"`
+}
+
+type DependencyStatus string
+
+const (
+	DependencyStatusUp   DependencyStatus = "up"
+	DependencyStatusDown DependencyStatus = "down"
+)
+
+type DependencyResponse struct {
+	Status    DependencyStatus `json:"status"`
+	Required  bool             `json:"required"`
+	LatencyMs int64            `json:"latencyMs"`
+	CheckedAt time.Time        `json:"checkedAt"`
+	LastError *DependencyError `json:"lastError,omitempty"`
+}
+
+// DependencyError describes the most recent failed probe. Detail is only set
+// in debug mode.
+type DependencyError struct {
+	Code   ErrorCode `json:"code"`
+	Detail *string   `json:"detail,omitempty"`
+	At     time.Time `json:"at"`
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import "github.com/kozennoki/nerine/internal/domain/entity"
+
+// listArticleFields is the default selection for paginated article lists,
+// which render cards and never need the body.
+var listArticleFields = []entity.ArticleField{
+	entity.ArticleFieldID,
+	entity.ArticleFieldTitle,
+	entity.ArticleFieldImage,
+	entity.ArticleFieldCategory,
+	entity.ArticleFieldPublishedAt,
+	entity.ArticleFieldCreatedAt,
+	entity.ArticleFieldUpdatedAt,
+}
+
+// summaryArticleFields is the default selection for the popular and latest
+// article widgets. UpdatedAt is kept so that the responses carry Last-Modified.
+var summaryArticleFields = []entity.ArticleField{
+	entity.ArticleFieldID,
+	entity.ArticleFieldTitle,
+	entity.ArticleFieldImage,
+	entity.ArticleFieldCategory,
+	entity.ArticleFieldPublishedAt,
+	entity.ArticleFieldUpdatedAt,
+}
+
+// ResolveFields returns the normalized requested fields, or defaults when
+// nothing was requested.
+func ResolveFields(requested, defaults []entity.ArticleField) []entity.ArticleField {
+	if len(requested) == 0 {
+		return defaults
+	}
+	return entity.NormalizeArticleFields(requested)
+}
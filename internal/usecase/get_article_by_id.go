@@ -13,6 +13,8 @@ type GetArticleByIDUsecase interface {
 
 type GetArticleByIDUsecaseInput struct {
 	ID string
+	// DraftKey, when set, reads the article including its unpublished draft.
+	DraftKey string
 }
 
 type GetArticleByIDUsecaseOutput struct {
@@ -35,7 +37,15 @@ func (u *getArticleByID) Exec(
 	ctx context.Context,
 	input GetArticleByIDUsecaseInput,
 ) (GetArticleByIDUsecaseOutput, error) {
-	article, err := u.articleRepo.GetArticleByID(ctx, input.ID)
+	var (
+		article *entity.Article
+		err     error
+	)
+	if input.DraftKey != "" {
+		article, err = u.articleRepo.GetDraftArticleByID(ctx, input.ID, input.DraftKey)
+	} else {
+		article, err = u.articleRepo.GetArticleByID(ctx, input.ID)
+	}
 	if err != nil {
 		return GetArticleByIDUsecaseOutput{}, err
 	}
@@ -58,6 +58,33 @@ func TestGetArticleByID_Exec(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "正常系: draftKey があれば下書きを取得する",
+			input: GetArticleByIDUsecaseInput{
+				ID:       "test-article-2",
+				DraftKey: "draft-key",
+			},
+			setupMock: func() {
+				article := &entity.Article{
+					ID:          "test-article-2",
+					Title:       "下書き記事",
+					Category:    entity.Category{Slug: "tech", Name: "技術"},
+					Description: "下書き記事の説明",
+					Body:        "下書き記事の本文",
+				}
+				mockRepo.EXPECT().GetDraftArticleByID(gomock.Any(), "test-article-2", "draft-key").Return(article, nil)
+			},
+			expected: GetArticleByIDUsecaseOutput{
+				Article: &entity.Article{
+					ID:          "test-article-2",
+					Title:       "下書き記事",
+					Category:    entity.Category{Slug: "tech", Name: "技術"},
+					Description: "下書き記事の説明",
+					Body:        "下書き記事の本文",
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			name: "異常系: 記事が見つからない",
 			input: GetArticleByIDUsecaseInput{
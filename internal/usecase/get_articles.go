@@ -15,11 +15,14 @@ type GetArticlesUsecase interface {
 type GetArticlesUsecaseInput struct {
 	Page  int
 	Limit int
+	// Fields selects the article fields to fetch. Empty means the list defaults.
+	Fields []entity.ArticleField
 }
 
 type GetArticlesUsecaseOutput struct {
 	Articles   []*entity.Article
 	Pagination utils.Pagination
+	Fields     []entity.ArticleField
 }
 
 type getArticles struct {
@@ -38,25 +41,19 @@ func (u *getArticles) Exec(
 	ctx context.Context,
 	input GetArticlesUsecaseInput,
 ) (GetArticlesUsecaseOutput, error) {
-	// Get total count for pagination
-	total, err := u.articleRepo.CountArticles(ctx)
-	if err != nil {
-		return GetArticlesUsecaseOutput{}, err
-	}
-
 	// Validate pagination parameters
-	limit, offset, pagination := BuildPagination(
-		input.Page, input.Limit, 10, 100, total,
-	)
+	page, limit, offset := ValidatePagination(input.Page, input.Limit, 10, 100)
+	fields := ResolveFields(input.Fields, listArticleFields)
 
-	// Get articles
-	articles, err := u.articleRepo.GetArticles(ctx, limit, offset)
+	// Get articles together with the total count
+	result, err := u.articleRepo.GetArticles(ctx, limit, offset, fields)
 	if err != nil {
 		return GetArticlesUsecaseOutput{}, err
 	}
 
 	return GetArticlesUsecaseOutput{
-		Articles:   articles,
-		Pagination: pagination,
+		Articles:   result.Articles,
+		Pagination: utils.NewPagination(result.Total, page, limit),
+		Fields:     fields,
 	}, nil
 }
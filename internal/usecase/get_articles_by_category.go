@@ -16,11 +16,14 @@ type GetArticlesByCategoryUsecaseInput struct {
 	CategorySlug string
 	Page         int
 	Limit        int
+	// Fields selects the article fields to fetch. Empty means the list defaults.
+	Fields []entity.ArticleField
 }
 
 type GetArticlesByCategoryUsecaseOutput struct {
 	Articles   []*entity.Article
 	Pagination utils.Pagination
+	Fields     []entity.ArticleField
 }
 
 type getArticlesByCategory struct {
@@ -39,25 +42,19 @@ func (u *getArticlesByCategory) Exec(
 	ctx context.Context,
 	input GetArticlesByCategoryUsecaseInput,
 ) (GetArticlesByCategoryUsecaseOutput, error) {
-	// Get total count for pagination
-	total, err := u.repo.CountArticlesByCategory(ctx, input.CategorySlug)
-	if err != nil {
-		return GetArticlesByCategoryUsecaseOutput{}, err
-	}
-
 	// Validate pagination parameters
-	limit, offset, pagination := BuildPagination(
-		input.Page, input.Limit, 10, 100, total,
-	)
+	page, limit, offset := ValidatePagination(input.Page, input.Limit, 10, 100)
+	fields := ResolveFields(input.Fields, listArticleFields)
 
-	// Get articles
-	articles, err := u.repo.GetArticlesByCategory(ctx, input.CategorySlug, limit, offset)
+	// Get articles together with the total count
+	result, err := u.repo.GetArticlesByCategory(ctx, input.CategorySlug, limit, offset, fields)
 	if err != nil {
 		return GetArticlesByCategoryUsecaseOutput{}, err
 	}
 
 	return GetArticlesByCategoryUsecaseOutput{
-		Articles:   articles,
-		Pagination: pagination,
+		Articles:   result.Articles,
+		Pagination: utils.NewPagination(result.Total, page, limit),
+		Fields:     fields,
 	}, nil
 }
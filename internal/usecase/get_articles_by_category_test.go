@@ -8,6 +8,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
 	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
 	"github.com/kozennoki/nerine/internal/usecase"
 )
@@ -45,8 +46,7 @@ func TestGetArticlesByCategory_Exec(t *testing.T) {
 						PublishedAt: time.Now(),
 					},
 				}
-				m.EXPECT().CountArticlesByCategory(gomock.Any(), "technology").Return(1, nil)
-				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 10, 0).Return(articles, nil)
+				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 10, 0, gomock.Any()).Return(repository.ArticlePage{Articles: articles, Total: 1}, nil)
 			},
 			wantLen:   1,
 			wantPage:  1,
@@ -75,8 +75,7 @@ func TestGetArticlesByCategory_Exec(t *testing.T) {
 						PublishedAt: time.Now(),
 					}
 				}
-				m.EXPECT().CountArticlesByCategory(gomock.Any(), "technology").Return(15, nil)
-				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 5, 5).Return(articles, nil)
+				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 5, 5, gomock.Any()).Return(repository.ArticlePage{Articles: articles, Total: 15}, nil)
 			},
 			wantLen:   5,
 			wantPage:  2,
@@ -105,27 +104,13 @@ func TestGetArticlesByCategory_Exec(t *testing.T) {
 						PublishedAt: time.Now(),
 					}
 				}
-				m.EXPECT().CountArticlesByCategory(gomock.Any(), "technology").Return(200, nil)
-				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 100, 0).Return(articles, nil)
+				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 100, 0, gomock.Any()).Return(repository.ArticlePage{Articles: articles, Total: 200}, nil)
 			},
 			wantLen:   100,
 			wantPage:  1,
 			wantTotal: 200,
 			wantErr:   false,
 		},
-		{
-			name: "count repository error",
-			input: usecase.GetArticlesByCategoryUsecaseInput{
-				CategorySlug: "technology",
-				Page:         1,
-				Limit:        10,
-			},
-			setupMock: func(m *mocks.MockArticleRepository) {
-				m.EXPECT().CountArticlesByCategory(gomock.Any(), "technology").Return(0, ErrRepository)
-			},
-			wantLen: 0,
-			wantErr: true,
-		},
 		{
 			name: "get articles repository error",
 			input: usecase.GetArticlesByCategoryUsecaseInput{
@@ -134,8 +119,7 @@ func TestGetArticlesByCategory_Exec(t *testing.T) {
 				Limit:        10,
 			},
 			setupMock: func(m *mocks.MockArticleRepository) {
-				m.EXPECT().CountArticlesByCategory(gomock.Any(), "technology").Return(10, nil)
-				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 10, 0).Return(nil, ErrRepository)
+				m.EXPECT().GetArticlesByCategory(gomock.Any(), "technology", 10, 0, gomock.Any()).Return(repository.ArticlePage{}, ErrRepository)
 			},
 			wantLen: 0,
 			wantErr: true,
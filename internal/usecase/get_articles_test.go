@@ -2,10 +2,12 @@ package usecase_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
 	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
 	"github.com/kozennoki/nerine/internal/usecase"
 	"go.uber.org/mock/gomock"
@@ -43,8 +45,7 @@ func TestGetArticles_Exec(t *testing.T) {
 						UpdatedAt:   time.Now(),
 					},
 				}
-				m.EXPECT().CountArticles(gomock.Any()).Return(1, nil)
-				m.EXPECT().GetArticles(gomock.Any(), 10, 0).Return(articles, nil)
+				m.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{Articles: articles, Total: 1}, nil)
 			},
 			wantLen:   1,
 			wantPage:  1,
@@ -72,26 +73,13 @@ func TestGetArticles_Exec(t *testing.T) {
 						UpdatedAt:   time.Now(),
 					}
 				}
-				m.EXPECT().CountArticles(gomock.Any()).Return(15, nil)
-				m.EXPECT().GetArticles(gomock.Any(), 5, 5).Return(articles, nil)
+				m.EXPECT().GetArticles(gomock.Any(), 5, 5, gomock.Any()).Return(repository.ArticlePage{Articles: articles, Total: 15}, nil)
 			},
 			wantLen:   5,
 			wantPage:  2,
 			wantTotal: 15,
 			wantErr:   false,
 		},
-		{
-			name: "count repository error",
-			input: usecase.GetArticlesUsecaseInput{
-				Page:  1,
-				Limit: 10,
-			},
-			setupMock: func(m *mocks.MockArticleRepository) {
-				m.EXPECT().CountArticles(gomock.Any()).Return(0, ErrRepository)
-			},
-			wantLen: 0,
-			wantErr: true,
-		},
 		{
 			name: "get articles repository error",
 			input: usecase.GetArticlesUsecaseInput{
@@ -99,8 +87,7 @@ func TestGetArticles_Exec(t *testing.T) {
 				Limit: 10,
 			},
 			setupMock: func(m *mocks.MockArticleRepository) {
-				m.EXPECT().CountArticles(gomock.Any()).Return(10, nil)
-				m.EXPECT().GetArticles(gomock.Any(), 10, 0).Return(nil, ErrRepository)
+				m.EXPECT().GetArticles(gomock.Any(), 10, 0, gomock.Any()).Return(repository.ArticlePage{}, ErrRepository)
 			},
 			wantLen: 0,
 			wantErr: true,
@@ -140,3 +127,57 @@ func TestGetArticles_Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestGetArticles_Exec_Fields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		fields     []entity.ArticleField
+		wantFields []entity.ArticleField
+	}{
+		{
+			name: "defaults leave out the body",
+			wantFields: []entity.ArticleField{
+				entity.ArticleFieldID,
+				entity.ArticleFieldTitle,
+				entity.ArticleFieldImage,
+				entity.ArticleFieldCategory,
+				entity.ArticleFieldPublishedAt,
+				entity.ArticleFieldCreatedAt,
+				entity.ArticleFieldUpdatedAt,
+			},
+		},
+		{
+			name:   "requested fields are normalized",
+			fields: []entity.ArticleField{entity.ArticleFieldBody, entity.ArticleFieldTitle, entity.ArticleFieldBody},
+			wantFields: []entity.ArticleField{
+				entity.ArticleFieldID,
+				entity.ArticleFieldTitle,
+				entity.ArticleFieldBody,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockArticleRepository(ctrl)
+			mockRepo.EXPECT().GetArticles(gomock.Any(), 10, 0, tt.wantFields).Return(repository.ArticlePage{}, nil)
+
+			uc := usecase.NewGetArticles(mockRepo)
+
+			got, err := uc.Exec(context.Background(), usecase.GetArticlesUsecaseInput{Fields: tt.fields})
+			if err != nil {
+				t.Fatalf("GetArticles.Exec() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Fields, tt.wantFields) {
+				t.Errorf("GetArticles.Exec() fields = %v, want %v", got.Fields, tt.wantFields)
+			}
+		})
+	}
+}
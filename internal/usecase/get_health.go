@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusOK HealthStatus = "ok"
+	// HealthStatusDegraded means the server is up but an upstream circuit is
+	// open, so requests that miss the cache fail fast.
+	HealthStatusDegraded HealthStatus = "degraded"
+)
+
+type GetHealthUsecase interface {
+	Exec(ctx context.Context, input GetHealthUsecaseInput) (GetHealthUsecaseOutput, error)
+}
+
+type GetHealthUsecaseInput struct{}
+
+type GetHealthUsecaseOutput struct {
+	Status    HealthStatus
+	Upstreams []*entity.UpstreamStatus
+}
+
+type getHealth struct {
+	upstreamMonitor repository.UpstreamMonitor
+}
+
+func NewGetHealth(
+	upstreamMonitor repository.UpstreamMonitor,
+) GetHealthUsecase {
+	return &getHealth{
+		upstreamMonitor: upstreamMonitor,
+	}
+}
+
+func (u *getHealth) Exec(
+	ctx context.Context,
+	input GetHealthUsecaseInput,
+) (GetHealthUsecaseOutput, error) {
+	upstreams, err := u.upstreamMonitor.GetUpstreamStatuses(ctx)
+	if err != nil {
+		return GetHealthUsecaseOutput{}, err
+	}
+
+	status := HealthStatusOK
+	for _, upstream := range upstreams {
+		if upstream.State == entity.CircuitOpen {
+			status = HealthStatusDegraded
+		}
+	}
+
+	return GetHealthUsecaseOutput{
+		Status:    status,
+		Upstreams: upstreams,
+	}, nil
+}
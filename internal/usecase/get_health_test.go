@@ -0,0 +1,76 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetHealth_Exec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		upstreams  []*entity.UpstreamStatus
+		wantStatus usecase.HealthStatus
+	}{
+		{
+			name: "all circuits closed",
+			upstreams: []*entity.UpstreamStatus{
+				{Name: "microcms", State: entity.CircuitClosed},
+				{Name: "zenn", State: entity.CircuitClosed},
+			},
+			wantStatus: usecase.HealthStatusOK,
+		},
+		{
+			name: "half-open circuit is still ok",
+			upstreams: []*entity.UpstreamStatus{
+				{Name: "microcms", State: entity.CircuitClosed},
+				{Name: "zenn", State: entity.CircuitHalfOpen},
+			},
+			wantStatus: usecase.HealthStatusOK,
+		},
+		{
+			name: "open circuit is degraded",
+			upstreams: []*entity.UpstreamStatus{
+				{Name: "microcms", State: entity.CircuitClosed},
+				{Name: "zenn", State: entity.CircuitOpen},
+			},
+			wantStatus: usecase.HealthStatusDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			monitor := mocks.NewMockUpstreamMonitor(ctrl)
+			monitor.EXPECT().GetUpstreamStatuses(gomock.Any()).Return(tt.upstreams, nil)
+
+			output, err := usecase.NewGetHealth(monitor).Exec(context.Background(), usecase.GetHealthUsecaseInput{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, output.Status)
+			assert.Equal(t, tt.upstreams, output.Upstreams)
+		})
+	}
+}
+
+func TestGetHealth_Exec_Error(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	monitor := mocks.NewMockUpstreamMonitor(ctrl)
+	monitor.EXPECT().GetUpstreamStatuses(gomock.Any()).Return(nil, ErrRepository)
+
+	_, err := usecase.NewGetHealth(monitor).Exec(context.Background(), usecase.GetHealthUsecaseInput{})
+
+	assert.ErrorIs(t, err, ErrRepository)
+}
@@ -13,10 +13,13 @@ type GetLatestArticlesUsecase interface {
 
 type GetLatestArticlesUsecaseInput struct {
 	Limit int
+	// Fields selects the article fields to fetch. Empty means the summary defaults.
+	Fields []entity.ArticleField
 }
 
 type GetLatestArticlesUsecaseOutput struct {
 	Articles []*entity.Article
+	Fields   []entity.ArticleField
 }
 
 type getLatestArticles struct {
@@ -36,13 +39,15 @@ func (u *getLatestArticles) Exec(
 	input GetLatestArticlesUsecaseInput,
 ) (GetLatestArticlesUsecaseOutput, error) {
 	limit := ValidateLimit(input.Limit, 5, 20)
+	fields := ResolveFields(input.Fields, summaryArticleFields)
 
-	articles, err := u.repo.GetLatestArticles(ctx, limit)
+	articles, err := u.repo.GetLatestArticles(ctx, limit, fields)
 	if err != nil {
 		return GetLatestArticlesUsecaseOutput{}, err
 	}
 
 	return GetLatestArticlesUsecaseOutput{
 		Articles: articles,
+		Fields:   fields,
 	}, nil
 }
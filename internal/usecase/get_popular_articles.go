@@ -13,10 +13,13 @@ type GetPopularArticlesUsecase interface {
 
 type GetPopularArticlesUsecaseInput struct {
 	Limit int
+	// Fields selects the article fields to fetch. Empty means the summary defaults.
+	Fields []entity.ArticleField
 }
 
 type GetPopularArticlesUsecaseOutput struct {
 	Articles []*entity.Article
+	Fields   []entity.ArticleField
 }
 
 type getPopularArticles struct {
@@ -36,13 +39,15 @@ func (u *getPopularArticles) Exec(
 	input GetPopularArticlesUsecaseInput,
 ) (GetPopularArticlesUsecaseOutput, error) {
 	limit := ValidateLimit(input.Limit, 5, 20)
+	fields := ResolveFields(input.Fields, summaryArticleFields)
 
-	articles, err := u.repo.GetPopularArticles(ctx, limit)
+	articles, err := u.repo.GetPopularArticles(ctx, limit, fields)
 	if err != nil {
 		return GetPopularArticlesUsecaseOutput{}, err
 	}
 
 	return GetPopularArticlesUsecaseOutput{
 		Articles: articles,
+		Fields:   fields,
 	}, nil
 }
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
@@ -42,7 +43,7 @@ func TestGetPopularArticles_Exec(t *testing.T) {
 						UpdatedAt:   time.Now(),
 					},
 				}
-				m.EXPECT().GetPopularArticles(gomock.Any(), 5).Return(articles, nil)
+				m.EXPECT().GetPopularArticles(gomock.Any(), 5, gomock.Any()).Return(articles, nil)
 			},
 			wantLen:     1,
 			wantErr:     false,
@@ -68,7 +69,7 @@ func TestGetPopularArticles_Exec(t *testing.T) {
 						UpdatedAt:   time.Now(),
 					}
 				}
-				m.EXPECT().GetPopularArticles(gomock.Any(), 10).Return(articles, nil)
+				m.EXPECT().GetPopularArticles(gomock.Any(), 10, gomock.Any()).Return(articles, nil)
 			},
 			wantLen:     10,
 			wantErr:     false,
@@ -94,7 +95,7 @@ func TestGetPopularArticles_Exec(t *testing.T) {
 						UpdatedAt:   time.Now(),
 					}
 				}
-				m.EXPECT().GetPopularArticles(gomock.Any(), 20).Return(articles, nil)
+				m.EXPECT().GetPopularArticles(gomock.Any(), 20, gomock.Any()).Return(articles, nil)
 			},
 			wantLen:     20,
 			wantErr:     false,
@@ -106,7 +107,7 @@ func TestGetPopularArticles_Exec(t *testing.T) {
 				Limit: 5,
 			},
 			setupMock: func(m *mocks.MockArticleRepository) {
-				m.EXPECT().GetPopularArticles(gomock.Any(), 5).Return(nil, ErrRepository)
+				m.EXPECT().GetPopularArticles(gomock.Any(), 5, gomock.Any()).Return(nil, ErrRepository)
 			},
 			wantLen: 0,
 			wantErr: true,
@@ -138,3 +139,31 @@ func TestGetPopularArticles_Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPopularArticles_Exec_DefaultFields(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wantFields := []entity.ArticleField{
+		entity.ArticleFieldID,
+		entity.ArticleFieldTitle,
+		entity.ArticleFieldImage,
+		entity.ArticleFieldCategory,
+		entity.ArticleFieldPublishedAt,
+		entity.ArticleFieldUpdatedAt,
+	}
+	mockRepo := mocks.NewMockArticleRepository(ctrl)
+	mockRepo.EXPECT().GetPopularArticles(gomock.Any(), 5, wantFields).Return([]*entity.Article{}, nil)
+
+	uc := usecase.NewGetPopularArticles(mockRepo)
+
+	got, err := uc.Exec(context.Background(), usecase.GetPopularArticlesUsecaseInput{})
+	if err != nil {
+		t.Fatalf("GetPopularArticles.Exec() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Fields, wantFields) {
+		t.Errorf("GetPopularArticles.Exec() fields = %v, want %v", got.Fields, wantFields)
+	}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type ReadinessStatus string
+
+const (
+	ReadinessStatusReady ReadinessStatus = "ready"
+	// ReadinessStatusNotReady means a required dependency failed its probe.
+	ReadinessStatusNotReady ReadinessStatus = "not_ready"
+)
+
+// ReadinessDependency is a source probed by listing a single article ID.
+// The reader must not be cached, or the probe would not reach the upstream.
+type ReadinessDependency struct {
+	Name     string
+	Required bool
+	Reader   repository.ArticleReader
+}
+
+type ReadinessOptions struct {
+	// CacheTTL is how long probe results are reused, so that frequent
+	// readiness checks do not hammer the upstreams. Zero probes every time.
+	CacheTTL     time.Duration
+	ProbeTimeout time.Duration
+}
+
+type GetReadinessUsecase interface {
+	Exec(ctx context.Context, input GetReadinessUsecaseInput) (GetReadinessUsecaseOutput, error)
+}
+
+type GetReadinessUsecaseInput struct{}
+
+type GetReadinessUsecaseOutput struct {
+	Status       ReadinessStatus
+	Dependencies []*entity.DependencyStatus
+}
+
+type getReadiness struct {
+	dependencies []ReadinessDependency
+	opts         ReadinessOptions
+
+	// mu is held while probing, so concurrent callers wait for and share
+	// a single round of probes.
+	mu        sync.Mutex
+	statuses  []entity.DependencyStatus
+	checkedAt time.Time
+}
+
+func NewGetReadiness(
+	dependencies []ReadinessDependency,
+	opts ReadinessOptions,
+) GetReadinessUsecase {
+	statuses := make([]entity.DependencyStatus, len(dependencies))
+	for i, dep := range dependencies {
+		statuses[i] = entity.DependencyStatus{
+			Name:     dep.Name,
+			Required: dep.Required,
+		}
+	}
+	return &getReadiness{
+		dependencies: dependencies,
+		opts:         opts,
+		statuses:     statuses,
+	}
+}
+
+func (u *getReadiness) Exec(
+	ctx context.Context,
+	input GetReadinessUsecaseInput,
+) (GetReadinessUsecaseOutput, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.checkedAt.IsZero() || time.Since(u.checkedAt) >= u.opts.CacheTTL {
+		u.probe(ctx)
+	}
+
+	status := ReadinessStatusReady
+	dependencies := make([]*entity.DependencyStatus, len(u.statuses))
+	for i := range u.statuses {
+		dep := u.statuses[i]
+		if dep.Required && !dep.Healthy {
+			status = ReadinessStatusNotReady
+		}
+		dependencies[i] = &dep
+	}
+
+	return GetReadinessUsecaseOutput{
+		Status:       status,
+		Dependencies: dependencies,
+	}, nil
+}
+
+// probe checks every dependency concurrently. The results are shared with
+// other callers, so the probes are not cancelled with the caller's request.
+func (u *getReadiness) probe(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i, dep := range u.dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, u.opts.ProbeTimeout)
+			defer cancel()
+
+			start := time.Now()
+			_, err := dep.Reader.GetArticles(probeCtx, 1, 0, []entity.ArticleField{entity.ArticleFieldID})
+
+			status := &u.statuses[i]
+			status.Healthy = err == nil
+			status.Latency = time.Since(start)
+			status.CheckedAt = start
+			if err != nil {
+				status.LastError = err
+				status.LastErrorAt = start
+			}
+		}()
+	}
+	wg.Wait()
+
+	u.checkedAt = time.Now()
+}
@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var probeFields = []entity.ArticleField{entity.ArticleFieldID}
+
+func TestGetReadiness_Exec(t *testing.T) {
+	t.Parallel()
+
+	upstreamErr := fmt.Errorf("failed to get articles: %w", repository.ErrUpstreamUnavailable)
+
+	tests := []struct {
+		name        string
+		microCMSErr error
+		zennErr     error
+		wantStatus  usecase.ReadinessStatus
+	}{
+		{
+			name:       "all dependencies up",
+			wantStatus: usecase.ReadinessStatusReady,
+		},
+		{
+			name:       "optional dependency down",
+			zennErr:    upstreamErr,
+			wantStatus: usecase.ReadinessStatusReady,
+		},
+		{
+			name:        "required dependency down",
+			microCMSErr: upstreamErr,
+			wantStatus:  usecase.ReadinessStatusNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			microCMS := mocks.NewMockArticleRepository(ctrl)
+			zenn := mocks.NewMockArticleReader(ctrl)
+			microCMS.EXPECT().GetArticles(gomock.Any(), 1, 0, probeFields).Return(repository.ArticlePage{}, tt.microCMSErr)
+			zenn.EXPECT().GetArticles(gomock.Any(), 1, 0, probeFields).Return(repository.ArticlePage{}, tt.zennErr)
+
+			uc := usecase.NewGetReadiness([]usecase.ReadinessDependency{
+				{Name: "microcms", Required: true, Reader: microCMS},
+				{Name: "zenn", Required: false, Reader: zenn},
+			}, usecase.ReadinessOptions{CacheTTL: time.Minute, ProbeTimeout: time.Second})
+
+			output, err := uc.Exec(context.Background(), usecase.GetReadinessUsecaseInput{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, output.Status)
+			require.Len(t, output.Dependencies, 2)
+
+			microCMSStatus := output.Dependencies[0]
+			assert.Equal(t, "microcms", microCMSStatus.Name)
+			assert.True(t, microCMSStatus.Required)
+			assert.Equal(t, tt.microCMSErr == nil, microCMSStatus.Healthy)
+			assert.Equal(t, tt.microCMSErr, microCMSStatus.LastError)
+			assert.False(t, microCMSStatus.CheckedAt.IsZero())
+
+			zennStatus := output.Dependencies[1]
+			assert.Equal(t, "zenn", zennStatus.Name)
+			assert.False(t, zennStatus.Required)
+			assert.Equal(t, tt.zennErr == nil, zennStatus.Healthy)
+			assert.Equal(t, tt.zennErr, zennStatus.LastError)
+		})
+	}
+}
+
+func TestGetReadiness_Exec_CachesProbes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockArticleReader(ctrl)
+	reader.EXPECT().GetArticles(gomock.Any(), 1, 0, probeFields).Return(repository.ArticlePage{}, nil).Times(1)
+
+	uc := usecase.NewGetReadiness([]usecase.ReadinessDependency{
+		{Name: "microcms", Required: true, Reader: reader},
+	}, usecase.ReadinessOptions{CacheTTL: time.Hour, ProbeTimeout: time.Second})
+
+	for range 3 {
+		output, err := uc.Exec(context.Background(), usecase.GetReadinessUsecaseInput{})
+		require.NoError(t, err)
+		assert.Equal(t, usecase.ReadinessStatusReady, output.Status)
+	}
+}
+
+func TestGetReadiness_Exec_KeepsLastError(t *testing.T) {
+	t.Parallel()
+
+	probeErr := errors.New("unauthorized")
+
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockArticleReader(ctrl)
+	gomock.InOrder(
+		reader.EXPECT().GetArticles(gomock.Any(), 1, 0, probeFields).Return(repository.ArticlePage{}, probeErr),
+		reader.EXPECT().GetArticles(gomock.Any(), 1, 0, probeFields).Return(repository.ArticlePage{}, nil),
+	)
+
+	uc := usecase.NewGetReadiness([]usecase.ReadinessDependency{
+		{Name: "microcms", Required: true, Reader: reader},
+	}, usecase.ReadinessOptions{CacheTTL: 0, ProbeTimeout: time.Second})
+
+	first, err := uc.Exec(context.Background(), usecase.GetReadinessUsecaseInput{})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ReadinessStatusNotReady, first.Status)
+
+	second, err := uc.Exec(context.Background(), usecase.GetReadinessUsecaseInput{})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ReadinessStatusReady, second.Status)
+	assert.True(t, second.Dependencies[0].Healthy)
+	assert.Equal(t, probeErr, second.Dependencies[0].LastError)
+	assert.Equal(t, first.Dependencies[0].LastErrorAt, second.Dependencies[0].LastErrorAt)
+}
+
+func TestGetReadiness_Exec_ProbeOutlivesCanceledRequest(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockArticleReader(ctrl)
+	reader.EXPECT().GetArticles(gomock.Any(), 1, 0, probeFields).
+		DoAndReturn(func(ctx context.Context, _, _ int, _ []entity.ArticleField) (repository.ArticlePage, error) {
+			return repository.ArticlePage{}, ctx.Err()
+		})
+
+	uc := usecase.NewGetReadiness([]usecase.ReadinessDependency{
+		{Name: "microcms", Required: true, Reader: reader},
+	}, usecase.ReadinessOptions{CacheTTL: time.Minute, ProbeTimeout: time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	output, err := uc.Exec(ctx, usecase.GetReadinessUsecaseInput{})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ReadinessStatusReady, output.Status)
+}
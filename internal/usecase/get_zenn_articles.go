@@ -38,17 +38,15 @@ func (u *getZennArticles) Exec(
 	ctx context.Context,
 	input GetZennArticlesUsecaseInput,
 ) (GetZennArticlesUsecaseOutput, error) {
-	limit, offset, pagination := BuildPagination(
-		input.Page, input.Limit, 10, 100, 0,
-	)
+	page, limit, offset := ValidatePagination(input.Page, input.Limit, 10, 100)
 
-	articles, err := u.zennRepo.GetArticles(ctx, limit, offset)
+	result, err := u.zennRepo.GetArticles(ctx, limit, offset, nil)
 	if err != nil {
 		return GetZennArticlesUsecaseOutput{}, err
 	}
 
 	return GetZennArticlesUsecaseOutput{
-		Articles:   articles,
-		Pagination: pagination,
+		Articles:   result.Articles,
+		Pagination: utils.NewPagination(result.Total, page, limit),
 	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
 	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
 	"github.com/kozennoki/nerine/internal/usecase"
 	"github.com/stretchr/testify/assert"
@@ -46,8 +47,8 @@ func TestGetZennArticles_Exec_Success(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetArticles(gomock.Any(), 10, 0).
-		Return(expectedArticles, nil).
+		GetArticles(gomock.Any(), 10, 0, nil).
+		Return(repository.ArticlePage{Articles: expectedArticles}, nil).
 		Times(1)
 
 	output, err := useCase.Exec(context.Background(), input)
@@ -117,8 +118,8 @@ func TestGetZennArticles_Exec_PaginationCalculation(t *testing.T) {
 			}
 
 			mockRepo.EXPECT().
-				GetArticles(gomock.Any(), tc.expectedLimit, tc.expectedOffset).
-				Return([]*entity.Article{}, nil).
+				GetArticles(gomock.Any(), tc.expectedLimit, tc.expectedOffset, nil).
+				Return(repository.ArticlePage{Articles: []*entity.Article{}}, nil).
 				Times(1)
 
 			_, err := useCase.Exec(context.Background(), input)
@@ -144,8 +145,8 @@ func TestGetZennArticles_Exec_RepositoryError(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetArticles(gomock.Any(), 10, 0).
-		Return(nil, expectedError).
+		GetArticles(gomock.Any(), 10, 0, nil).
+		Return(repository.ArticlePage{}, expectedError).
 		Times(1)
 
 	output, err := useCase.Exec(context.Background(), input)
@@ -170,8 +171,8 @@ func TestGetZennArticles_Exec_EmptyResponse(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetArticles(gomock.Any(), 10, 0).
-		Return([]*entity.Article{}, nil).
+		GetArticles(gomock.Any(), 10, 0, nil).
+		Return(repository.ArticlePage{Articles: []*entity.Article{}}, nil).
 		Times(1)
 
 	output, err := useCase.Exec(context.Background(), input)
@@ -202,8 +203,8 @@ func TestGetZennArticles_Exec_ContextCancellation(t *testing.T) {
 	expectedError := context.Canceled
 
 	mockRepo.EXPECT().
-		GetArticles(gomock.Any(), 10, 0).
-		Return(nil, expectedError).
+		GetArticles(gomock.Any(), 10, 0, nil).
+		Return(repository.ArticlePage{}, expectedError).
 		Times(1)
 
 	output, err := useCase.Exec(ctx, input)
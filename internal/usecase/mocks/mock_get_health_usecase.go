@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/get_health.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/get_health.go -destination=internal/usecase/mocks/mock_get_health_usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecase "github.com/kozennoki/nerine/internal/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGetHealthUsecase is a mock of GetHealthUsecase interface.
+type MockGetHealthUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetHealthUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockGetHealthUsecaseMockRecorder is the mock recorder for MockGetHealthUsecase.
+type MockGetHealthUsecaseMockRecorder struct {
+	mock *MockGetHealthUsecase
+}
+
+// NewMockGetHealthUsecase creates a new mock instance.
+func NewMockGetHealthUsecase(ctrl *gomock.Controller) *MockGetHealthUsecase {
+	mock := &MockGetHealthUsecase{ctrl: ctrl}
+	mock.recorder = &MockGetHealthUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetHealthUsecase) EXPECT() *MockGetHealthUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockGetHealthUsecase) Exec(ctx context.Context, input usecase.GetHealthUsecaseInput) (usecase.GetHealthUsecaseOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", ctx, input)
+	ret0, _ := ret[0].(usecase.GetHealthUsecaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockGetHealthUsecaseMockRecorder) Exec(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockGetHealthUsecase)(nil).Exec), ctx, input)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/get_readiness.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/get_readiness.go -destination=internal/usecase/mocks/mock_get_readiness_usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecase "github.com/kozennoki/nerine/internal/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGetReadinessUsecase is a mock of GetReadinessUsecase interface.
+type MockGetReadinessUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetReadinessUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockGetReadinessUsecaseMockRecorder is the mock recorder for MockGetReadinessUsecase.
+type MockGetReadinessUsecaseMockRecorder struct {
+	mock *MockGetReadinessUsecase
+}
+
+// NewMockGetReadinessUsecase creates a new mock instance.
+func NewMockGetReadinessUsecase(ctrl *gomock.Controller) *MockGetReadinessUsecase {
+	mock := &MockGetReadinessUsecase{ctrl: ctrl}
+	mock.recorder = &MockGetReadinessUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetReadinessUsecase) EXPECT() *MockGetReadinessUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockGetReadinessUsecase) Exec(ctx context.Context, input usecase.GetReadinessUsecaseInput) (usecase.GetReadinessUsecaseOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", ctx, input)
+	ret0, _ := ret[0].(usecase.GetReadinessUsecaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockGetReadinessUsecaseMockRecorder) Exec(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockGetReadinessUsecase)(nil).Exec), ctx, input)
+}
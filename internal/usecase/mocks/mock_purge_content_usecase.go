@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/purge_content.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/purge_content.go -destination=internal/usecase/mocks/mock_purge_content_usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecase "github.com/kozennoki/nerine/internal/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPurgeContentUsecase is a mock of PurgeContentUsecase interface.
+type MockPurgeContentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurgeContentUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPurgeContentUsecaseMockRecorder is the mock recorder for MockPurgeContentUsecase.
+type MockPurgeContentUsecaseMockRecorder struct {
+	mock *MockPurgeContentUsecase
+}
+
+// NewMockPurgeContentUsecase creates a new mock instance.
+func NewMockPurgeContentUsecase(ctrl *gomock.Controller) *MockPurgeContentUsecase {
+	mock := &MockPurgeContentUsecase{ctrl: ctrl}
+	mock.recorder = &MockPurgeContentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurgeContentUsecase) EXPECT() *MockPurgeContentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockPurgeContentUsecase) Exec(ctx context.Context, input usecase.PurgeContentUsecaseInput) (usecase.PurgeContentUsecaseOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", ctx, input)
+	ret0, _ := ret[0].(usecase.PurgeContentUsecaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockPurgeContentUsecaseMockRecorder) Exec(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockPurgeContentUsecase)(nil).Exec), ctx, input)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/record_article_view.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/record_article_view.go -destination=internal/usecase/mocks/mock_record_article_view_usecase.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecase "github.com/kozennoki/nerine/internal/usecase"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecordArticleViewUsecase is a mock of RecordArticleViewUsecase interface.
+type MockRecordArticleViewUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordArticleViewUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockRecordArticleViewUsecaseMockRecorder is the mock recorder for MockRecordArticleViewUsecase.
+type MockRecordArticleViewUsecaseMockRecorder struct {
+	mock *MockRecordArticleViewUsecase
+}
+
+// NewMockRecordArticleViewUsecase creates a new mock instance.
+func NewMockRecordArticleViewUsecase(ctrl *gomock.Controller) *MockRecordArticleViewUsecase {
+	mock := &MockRecordArticleViewUsecase{ctrl: ctrl}
+	mock.recorder = &MockRecordArticleViewUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordArticleViewUsecase) EXPECT() *MockRecordArticleViewUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *MockRecordArticleViewUsecase) Exec(ctx context.Context, input usecase.RecordArticleViewUsecaseInput) (usecase.RecordArticleViewUsecaseOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", ctx, input)
+	ret0, _ := ret[0].(usecase.RecordArticleViewUsecaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockRecordArticleViewUsecaseMockRecorder) Exec(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockRecordArticleViewUsecase)(nil).Exec), ctx, input)
+}
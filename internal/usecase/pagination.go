@@ -2,9 +2,9 @@ package usecase
 
 import "github.com/kozennoki/nerine/internal/infrastructure/utils"
 
-// BuildPagination validates page and limit parameters and builds pagination info
-// Returns: validatedLimit, offset, pagination
-func BuildPagination(page, limit, defaultLimit, maxLimit int, total int) (int, int, utils.Pagination) {
+// ValidatePagination validates page and limit parameters before the total is known
+// Returns: validatedPage, validatedLimit, offset
+func ValidatePagination(page, limit, defaultLimit, maxLimit int) (int, int, int) {
 	validatedPage := page
 	if validatedPage < 1 {
 		validatedPage = 1
@@ -19,9 +19,8 @@ func BuildPagination(page, limit, defaultLimit, maxLimit int, total int) (int, i
 	}
 
 	offset := utils.ConvertPageToOffset(validatedPage, validatedLimit)
-	pagination := utils.NewPagination(total, validatedPage, validatedLimit)
 
-	return validatedLimit, offset, pagination
+	return validatedPage, validatedLimit, offset
 }
 
 // ValidateLimit validates limit parameter with default and maximum values
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+// ContentKind identifies the kind of content that changed upstream.
+type ContentKind int
+
+const (
+	ContentKindUnknown ContentKind = iota
+	ContentKindArticle
+	ContentKindCategory
+)
+
+type PurgeContentUsecase interface {
+	Exec(ctx context.Context, input PurgeContentUsecaseInput) (PurgeContentUsecaseOutput, error)
+}
+
+type PurgeContentUsecaseInput struct {
+	Kind ContentKind
+	// ID is the changed content. An empty ID for articles purges every article.
+	ID string
+	// CategorySlugs are the categories the article belonged to before and
+	// after the change.
+	CategorySlugs []string
+}
+
+type PurgeContentUsecaseOutput struct {
+	// Purged is false when the content kind is not cached.
+	Purged bool
+}
+
+type purgeContent struct {
+	invalidator repository.ContentCacheInvalidator
+}
+
+func NewPurgeContent(
+	invalidator repository.ContentCacheInvalidator,
+) PurgeContentUsecase {
+	return &purgeContent{
+		invalidator: invalidator,
+	}
+}
+
+func (u *purgeContent) Exec(
+	ctx context.Context,
+	input PurgeContentUsecaseInput,
+) (PurgeContentUsecaseOutput, error) {
+	switch input.Kind {
+	case ContentKindArticle:
+		if err := u.invalidator.InvalidateArticle(ctx, input.ID, input.CategorySlugs); err != nil {
+			return PurgeContentUsecaseOutput{}, fmt.Errorf("failed to invalidate article: %w", err)
+		}
+	case ContentKindCategory:
+		if err := u.invalidator.InvalidateCategory(ctx, input.ID); err != nil {
+			return PurgeContentUsecaseOutput{}, fmt.Errorf("failed to invalidate category: %w", err)
+		}
+	default:
+		return PurgeContentUsecaseOutput{}, nil
+	}
+
+	return PurgeContentUsecaseOutput{Purged: true}, nil
+}
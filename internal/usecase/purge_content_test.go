@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeContent_Exec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		input      usecase.PurgeContentUsecaseInput
+		setupMock  func(*mocks.MockContentCacheInvalidator)
+		wantPurged bool
+		wantErr    bool
+	}{
+		{
+			name: "article change purges the article and its categories",
+			input: usecase.PurgeContentUsecaseInput{
+				Kind:          usecase.ContentKindArticle,
+				ID:            "article-1",
+				CategorySlugs: []string{"go", "golang"},
+			},
+			setupMock: func(m *mocks.MockContentCacheInvalidator) {
+				m.EXPECT().InvalidateArticle(gomock.Any(), "article-1", []string{"go", "golang"}).Return(nil)
+			},
+			wantPurged: true,
+		},
+		{
+			name: "category change purges the category",
+			input: usecase.PurgeContentUsecaseInput{
+				Kind: usecase.ContentKindCategory,
+				ID:   "go",
+			},
+			setupMock: func(m *mocks.MockContentCacheInvalidator) {
+				m.EXPECT().InvalidateCategory(gomock.Any(), "go").Return(nil)
+			},
+			wantPurged: true,
+		},
+		{
+			name: "unknown content is ignored",
+			input: usecase.PurgeContentUsecaseInput{
+				Kind: usecase.ContentKindUnknown,
+				ID:   "x",
+			},
+			setupMock:  func(m *mocks.MockContentCacheInvalidator) {},
+			wantPurged: false,
+		},
+		{
+			name: "invalidator error",
+			input: usecase.PurgeContentUsecaseInput{
+				Kind: usecase.ContentKindCategory,
+				ID:   "go",
+			},
+			setupMock: func(m *mocks.MockContentCacheInvalidator) {
+				m.EXPECT().InvalidateCategory(gomock.Any(), "go").Return(ErrRepository)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockInvalidator := mocks.NewMockContentCacheInvalidator(ctrl)
+			tt.setupMock(mockInvalidator)
+
+			uc := usecase.NewPurgeContent(mockInvalidator)
+
+			got, err := uc.Exec(context.Background(), tt.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PurgeContent.Exec() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Purged != tt.wantPurged {
+				t.Errorf("PurgeContent.Exec() purged = %v, want %v", got.Purged, tt.wantPurged)
+			}
+		})
+	}
+}
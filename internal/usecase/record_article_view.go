@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/kozennoki/nerine/internal/domain/repository"
+)
+
+type RecordArticleViewUsecase interface {
+	Exec(ctx context.Context, input RecordArticleViewUsecaseInput) (RecordArticleViewUsecaseOutput, error)
+}
+
+type RecordArticleViewUsecaseInput struct {
+	ArticleID string
+	// Visitor identifies the reader, so that repeated views are counted once.
+	Visitor string
+}
+
+type RecordArticleViewUsecaseOutput struct {
+	// Counted is false when the visitor had already viewed the article.
+	Counted bool
+}
+
+type recordArticleView struct {
+	articleRepo    repository.ArticleRepository
+	popularityRepo repository.PopularityRepository
+}
+
+func NewRecordArticleView(
+	articleRepo repository.ArticleRepository,
+	popularityRepo repository.PopularityRepository,
+) RecordArticleViewUsecase {
+	return &recordArticleView{
+		articleRepo:    articleRepo,
+		popularityRepo: popularityRepo,
+	}
+}
+
+func (u *recordArticleView) Exec(
+	ctx context.Context,
+	input RecordArticleViewUsecaseInput,
+) (RecordArticleViewUsecaseOutput, error) {
+	// Only published articles are ranked, and unknown IDs must not fill the
+	// store; the article lookup is normally answered by the cache.
+	if _, err := u.articleRepo.GetArticleByID(ctx, input.ArticleID); err != nil {
+		return RecordArticleViewUsecaseOutput{}, err
+	}
+
+	counted, err := u.popularityRepo.RecordView(ctx, input.ArticleID, input.Visitor)
+	if err != nil {
+		return RecordArticleViewUsecaseOutput{}, err
+	}
+
+	return RecordArticleViewUsecaseOutput{
+		Counted: counted,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kozennoki/nerine/internal/domain/entity"
+	"github.com/kozennoki/nerine/internal/domain/repository"
+	"github.com/kozennoki/nerine/internal/domain/repository/mocks"
+	"github.com/kozennoki/nerine/internal/usecase"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecordArticleView_Exec(t *testing.T) {
+	t.Parallel()
+
+	notFound := fmt.Errorf("failed to get article by ID: %w", repository.ErrNotFound)
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mocks.MockArticleRepository, *mocks.MockPopularityRepository)
+		wantCounted bool
+		wantErr     error
+	}{
+		{
+			name: "view of a published article is counted",
+			setupMock: func(a *mocks.MockArticleRepository, p *mocks.MockPopularityRepository) {
+				a.EXPECT().GetArticleByID(gomock.Any(), "article-1").Return(&entity.Article{ID: "article-1"}, nil)
+				p.EXPECT().RecordView(gomock.Any(), "article-1", "visitor-1").Return(true, nil)
+			},
+			wantCounted: true,
+		},
+		{
+			name: "repeated view is not counted",
+			setupMock: func(a *mocks.MockArticleRepository, p *mocks.MockPopularityRepository) {
+				a.EXPECT().GetArticleByID(gomock.Any(), "article-1").Return(&entity.Article{ID: "article-1"}, nil)
+				p.EXPECT().RecordView(gomock.Any(), "article-1", "visitor-1").Return(false, nil)
+			},
+			wantCounted: false,
+		},
+		{
+			name: "unknown article is not recorded",
+			setupMock: func(a *mocks.MockArticleRepository, p *mocks.MockPopularityRepository) {
+				a.EXPECT().GetArticleByID(gomock.Any(), "article-1").Return(nil, notFound)
+			},
+			wantErr: repository.ErrNotFound,
+		},
+		{
+			name: "store error",
+			setupMock: func(a *mocks.MockArticleRepository, p *mocks.MockPopularityRepository) {
+				a.EXPECT().GetArticleByID(gomock.Any(), "article-1").Return(&entity.Article{ID: "article-1"}, nil)
+				p.EXPECT().RecordView(gomock.Any(), "article-1", "visitor-1").Return(false, ErrRepository)
+			},
+			wantErr: ErrRepository,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockArticleRepo := mocks.NewMockArticleRepository(ctrl)
+			mockPopularityRepo := mocks.NewMockPopularityRepository(ctrl)
+			tt.setupMock(mockArticleRepo, mockPopularityRepo)
+
+			uc := usecase.NewRecordArticleView(mockArticleRepo, mockPopularityRepo)
+
+			got, err := uc.Exec(context.Background(), usecase.RecordArticleViewUsecaseInput{
+				ArticleID: "article-1",
+				Visitor:   "visitor-1",
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RecordArticleView.Exec() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got.Counted != tt.wantCounted {
+				t.Errorf("RecordArticleView.Exec() counted = %v, want %v", got.Counted, tt.wantCounted)
+			}
+		})
+	}
+}